@@ -47,8 +47,9 @@ func TestEndToEnd(t *testing.T) {
 			t.Errorf("%s is not a Go file", name)
 			continue
 		}
-		if strings.HasPrefix(name, "tag_") || strings.HasPrefix(name, "vary_") {
-			// This file is used for tag processing in TestTags or TestConstValueChange, below.
+		if strings.HasPrefix(name, "tag_") || strings.HasPrefix(name, "vary_") || strings.HasPrefix(name, "multi_") {
+			// This file is used for tag processing in TestTags, TestConstValueChange
+			// or TestMultipleFiles, below.
 			continue
 		}
 		if name == "cgo.go" && !build.Default.CgoEnabled {
@@ -59,6 +60,19 @@ func TestEndToEnd(t *testing.T) {
 		typeName := fmt.Sprintf("%c%s", name[0]+'A'-'a', name[1:len(name)-len(".go")])
 		stringerCompileAndRun(t, dir, stringer, typeName, name)
 	}
+	// Run some of the programs again with additional flags.
+	for _, x := range []struct {
+		name  string
+		flags []string
+	}{
+		{"linecomment.go", []string{"-linecomment", "-json"}},
+		{"country.go", []string{"-linecomment", "-json"}},
+		{"gap.go", []string{"-values"}},
+		{"status.go", []string{"-sparse=search", "-sql", "-values"}},
+	} {
+		typeName := fmt.Sprintf("%c%s", x.name[0]+'A'-'a', x.name[1:len(x.name)-len(".go")])
+		stringerCompileAndRun(t, dir, stringer, typeName, x.name, x.flags...)
+	}
 }
 
 // TestTags verifies that the -tags flag works as advertised.
@@ -125,7 +139,7 @@ func TestConstValueChange(t *testing.T) {
 		t.Fatal(err)
 	}
 	// Run the binary in the temporary directory as a sanity check.
-	err = run("go", "run", stringSource, source)
+	err = run("go", "run", stringSource, filepath.Join(dir, "enum_errors.go"), source)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -144,16 +158,39 @@ func TestConstValueChange(t *testing.T) {
 	// matches a set of possible error strings emitted by known
 	// Go compilers.
 	fmt.Fprintf(os.Stderr, "Note: the following messages should indicate an out-of-bounds compiler error\n")
-	if generateTests {
-		err = runInDir(dir, "go", "test")
-	} else {
-		err = run("go", "build", stringSource, source)
-	}
+	err = runInDir(dir, "go", "test")
 	if err == nil {
 		t.Fatal("unexpected compiler success")
 	}
 }
 
+// TestMultipleFiles verifies that the constants of a type declared in several
+// files are collected, including an alias of a constant in another file.
+func TestMultipleFiles(t *testing.T) {
+	dir, stringer := buildStringer(t)
+	defer os.RemoveAll(dir)
+	files := []string{"multi_pill.go", "multi_pill_alias.go"}
+	for _, file := range files {
+		err := copy(filepath.Join(dir, file), filepath.Join("testdata", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Run stringer in the directory that contains the package files.
+	err := runInDir(dir, stringer, "-type", "Pill", "-values", ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = runInDir(dir, "go", "run", "pill_string.go", "enum_errors.go", files[0], files[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = runInDir(dir, "go", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 // buildStringer creates a temporary directory and installs stringer there.
 func buildStringer(t *testing.T) (dir string, stringer string) {
 	t.Helper()
@@ -173,11 +210,12 @@ func buildStringer(t *testing.T) (dir string, stringer string) {
 
 // stringerCompileAndRun runs stringer for the named file and compiles and
 // runs the target binary in directory dir. That binary will panic if the String method is incorrect.
-func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName string) {
+// Stringer is run with the given flags, or with those of the type if there are none.
+func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName string, flags ...string) {
 	t.Helper()
-	t.Logf("run: %s %s\n", fileName, typeName)
+	t.Logf("run: %s\n", strings.Join(append([]string{fileName, typeName}, flags...), " "))
 
-	dir = filepath.Join(dir, typeName)
+	dir = filepath.Join(dir, typeName+strings.Join(flags, ""))
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		t.Fatal(err)
 	}
@@ -189,16 +227,57 @@ func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName strin
 	}
 	stringSource := filepath.Join(dir, typeName+"_string.go")
 	// Run stringer in temporary directory.
-	if typeName == "Linecomment" || typeName == "Country" {
+	switch {
+	case len(flags) != 0:
+		err = run(stringer, append(flags, "-type", typeName, "-output", stringSource, source)...)
+	case typeName == "Linecomment", typeName == "Country":
 		err = run(stringer, "-linecomment", "-type", typeName, "-output", stringSource, source)
-	} else {
+	case typeName == "Flags":
+		err = run(stringer, "-bitmask", "-values", "-json", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Alias", typeName == "Nocase":
+		err = run(stringer, "-nocase", "-values", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Phash":
+		err = run(stringer, "-lookup=phash", "-nocase", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Transform":
+		err = run(stringer, "-trimprefix=Transform", "-transform=kebab", "-linecomment", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Tags":
+		err = run(stringer, "-sql", "-nocase", "-linecomment", "-json", "-yaml", "-slog=name", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Sentinel":
+		err = run(stringer, "-exclude=^num", "-values", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Deprecated":
+		err = run(stringer, "-replacedeprecated", "-values", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Fallback":
+		err = run(stringer, "-linecomment", "-lookup=map", "-sql", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Lenient":
+		err = run(stringer, "-linecomment", "-lenient", "-sql", "-lookup=map", "-json", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Encoding":
+		err = run(stringer, "-encoding=number,name", "-sql", "-yaml", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Unsigned":
+		err = run(stringer, "-encoding=number", "-lenient", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Binary":
+		err = run(stringer, "-binary", "-json", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Severity":
+		err = run(stringer, "-slog=group", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Episode":
+		err = run(stringer, "-graphql", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Rating":
+		err = run(stringer, "-graphql", "-lenient", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Mode":
+		err = run(stringer, "-formatter", "-bitmask", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Unit":
+		err = run(stringer, "-xml", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Stralias":
+		err = run(stringer, "-nocase", "-lookup=map", "-json", "-sql", "-type", typeName, "-output", stringSource, source)
+	case typeName == "Strenum":
+		err = run(stringer, "-sql", "-values", "-json", "-yaml", "-xml", "-type", typeName, "-output", stringSource, source)
+	default:
 		err = run(stringer, "-type", typeName, "-output", stringSource, source)
 	}
 	if err != nil {
 		t.Fatal(err)
 	}
 	// Run the binary in the temporary directory.
-	err = run("go", "run", stringSource, source)
+	err = run("go", "run", stringSource, filepath.Join(dir, "enum_errors.go"), source)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,493 @@
+// This file contains tests for the errors returned by Generate.
+
+package generator
+
+import (
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/charlievieth/go-enum/internal/testenv"
+)
+
+type GenerateErrorTest struct {
+	name   string
+	opts   Options
+	input  string // input; the package clause is provided when running the test.
+	errstr string // expected substring of the error.
+}
+
+var generateErrorTests = []GenerateErrorTest{
+	{
+		"novalues",
+		Options{},
+		"type T int\n",
+		"no values defined for type T",
+	},
+	{
+		"float",
+		Options{},
+		"type T float64\nconst (\n\tA T = 1.5\n\tB T = 2.5\n)\n",
+		"can't handle non-integer constant type T",
+	},
+	{
+		"primary",
+		Options{},
+		"type T int\nconst (\n\t//enum:primary\n\tA T = 1\n\t//enum:primary\n\tB T = 1\n)\n",
+		"found multiple primary constants with the value 1: A and B",
+	},
+	{
+		"directive",
+		Options{},
+		"type T int\nconst (\n\tA T = 1 //enum:unknown\n)\n",
+		"unknown directive: //enum:unknown",
+	},
+	{
+		"directive_alias",
+		Options{},
+		"type T int\nconst (\n\tA T = 1 //enum:alias=a,,b\n)\n",
+		"empty alias name: //enum:alias=a,,b",
+	},
+	{
+		"directive_primary",
+		Options{},
+		"type T int\nconst (\n\tA T = 1 //enum:primary=yes\n)\n",
+		"unexpected argument: //enum:primary=yes",
+	},
+	{
+		"duplicate_directive_alias",
+		Options{},
+		"type T int\nconst (\n\tA T = 1 //enum:alias=B\n\tB T = 2\n)\n",
+		"values with duplicate strings representations",
+	},
+	{
+		"directive_skip",
+		Options{},
+		"type T int\nconst (\n\tA T = 1 //enum:skip=yes\n)\n",
+		"unexpected argument: //enum:skip=yes",
+	},
+	{
+		"directive_default",
+		Options{},
+		"type T int\nconst (\n\tA T = 1 //enum:default\n\tB T = 2 //enum:default\n)\n",
+		"found multiple default constants: A and B",
+	},
+	{
+		"default_bitmask",
+		Options{Bitmask: true},
+		"type T uint\nconst (\n\tA T = 1 //enum:default\n)\n",
+		"the default directive cannot be used with bitmask type T",
+	},
+	{
+		"exclude",
+		Options{Exclude: "(max"},
+		"type T int\nconst (\n\tA T = 1\n)\n",
+		"invalid exclude pattern",
+	},
+	{
+		"exclude_all",
+		Options{Exclude: "."},
+		"type T int\nconst (\n\tA T = 1\n)\n",
+		"no values defined for type T",
+	},
+	{
+		"deprecated_bitmask",
+		Options{Bitmask: true, ReplaceDeprecated: true},
+		"type T uint\nconst (\n\t// Deprecated: Use B instead.\n\tA T = 1\n\tB T = 2\n)\n",
+		"cannot replace the deprecated constants of type T",
+	},
+	{
+		"duplicate_alias",
+		Options{LineComment: true},
+		"type T int\nconst (\n\tA T = 1 // X\n\tB T = 2 // Y\n\tC T = 2 // X\n)\n",
+		"values with duplicate strings representations",
+	},
+	{
+		"duplicate_strings",
+		Options{LineComment: true},
+		"type T int\nconst (\n\tA T = 1 // X\n\tB T = 2 // X\n)\n",
+		"values with duplicate strings representations",
+	},
+	{
+		"nocase",
+		Options{LineComment: true, NoCase: true},
+		"type T int\nconst (\n\tA T = 1 // ab\n\tB T = 2 // AB\n)\n",
+		"only differ by case",
+	},
+	{
+		"bitmask",
+		Options{Bitmask: true},
+		"type T uint\nconst (\n\tA T = 1\n\tB T = 3\n)\n",
+		"is not a single bit",
+	},
+	{
+		"lookup",
+		Options{Lookup: "tree"},
+		"type T int\nconst (\n\tA T = 1\n)\n",
+		`invalid lookup strategy: "tree"`,
+	},
+	{
+		"lookup_bitmask",
+		Options{Bitmask: true, Lookup: "phash"},
+		"type T uint\nconst (\n\tA T = 1\n)\n",
+		`lookup strategy "phash" cannot be used with bitmask types`,
+	},
+	{
+		"sparse",
+		Options{Sparse: "tree"},
+		"type T int\nconst (\n\tA T = 1\n)\n",
+		`invalid sparse strategy: "tree"`,
+	},
+	{
+		"transform",
+		Options{Transform: "camel"},
+		"type T int\nconst (\n\tA T = 1\n)\n",
+		`invalid transform: "camel"`,
+	},
+	{
+		"transform_duplicate",
+		Options{Transform: "lower"},
+		"type T int\nconst (\n\tAB T = 1\n\tAb T = 2\n)\n",
+		"values with duplicate strings representations",
+	},
+	{
+		"tags_duplicate",
+		Options{LineComment: true},
+		"type T int\nconst (\n\tA T = 1 // json:\"a\"\n\tB T = 2 // json:\"a\"\n)\n",
+		"values with duplicate strings representations",
+	},
+	{
+		"tags_bitmask",
+		Options{Bitmask: true, LineComment: true},
+		"type T uint\nconst (\n\tA T = 1 // json:\"a\"\n)\n",
+		"json and sql names cannot be used with bitmask type T",
+	},
+	{
+		"tags_unknown",
+		Options{LineComment: true},
+		"type T int\nconst (\n\tA T = 1 // jsn:\"a\"\n)\n",
+		"unknown key \"jsn\" in the line comment of A",
+	},
+	{
+		"lenient_bitmask",
+		Options{Bitmask: true, Lenient: true},
+		"type T uint\nconst (\n\tA T = 1\n)\n",
+		"lenient parsing cannot be used with bitmask types",
+	},
+	{
+		"lenient_string",
+		Options{Lenient: true},
+		"type T string\nconst (\n\tA T = \"a\"\n)\n",
+		"cannot parse type T leniently",
+	},
+	{
+		"encoding",
+		Options{Encoding: "int"},
+		"type T int\nconst (\n\tA T = 1\n)\n",
+		`invalid encoding: "int"`,
+	},
+	{
+		"encoding_bitmask",
+		Options{Bitmask: true, Encoding: "number"},
+		"type T uint\nconst (\n\tA T = 1\n)\n",
+		`encoding "number" cannot be used with bitmask types`,
+	},
+	{
+		"encoding_tags",
+		Options{Encoding: "number", LineComment: true},
+		"type T int\nconst (\n\tA T = 1 // json:\"a\"\n)\n",
+		"json names cannot be used with the number encoding of type T",
+	},
+	{
+		"encoding_string",
+		Options{Encoding: "number,name"},
+		"type T string\nconst (\n\tA T = \"a\"\n)\n",
+		"cannot encode type T as numbers",
+	},
+	{
+		"binary_string",
+		Options{Binary: true},
+		"type T string\nconst (\n\tA T = \"a\"\n)\n",
+		"cannot encode type T as binary",
+	},
+	{
+		"slog",
+		Options{Slog: "text"},
+		"type T int\nconst (\n\tA T = 1\n)\n",
+		`invalid slog format: "text"`,
+	},
+	{
+		"slog_string",
+		Options{Slog: "group"},
+		"type T string\nconst (\n\tA T = \"a\"\n)\n",
+		"cannot log type T as a group",
+	},
+	{
+		"formatter_string",
+		Options{Formatter: true},
+		"type T string\nconst (\n\tA T = \"a\"\n)\n",
+		"cannot format type T as a number",
+	},
+	{
+		"trimprefix_string",
+		Options{TrimPrefix: "A"},
+		"type T string\nconst (\n\tA T = \"a\"\n)\n",
+		"cannot change the names of type T",
+	},
+	{
+		"transform_string",
+		Options{Transform: "snake"},
+		"type T string\nconst (\n\tA T = \"a\"\n)\n",
+		"cannot change the names of type T",
+	},
+	{
+		"linecomment_string",
+		Options{LineComment: true},
+		"type T string\nconst (\n\tA T = \"a\" // b\n)\n",
+		"cannot change the names of type T",
+	},
+	{
+		"graphql_bitmask",
+		Options{Bitmask: true, GraphQL: true},
+		"type T uint\nconst (\n\tA T = 1\n)\n",
+		"GraphQL enums cannot be generated for bitmask types",
+	},
+	{
+		"graphql_transform",
+		Options{GraphQL: true, GraphQLTransform: "kebab"},
+		"type T int\nconst (\n\tA T = 1\n)\n",
+		`invalid GraphQL transform: "kebab"`,
+	},
+	{
+		"graphql_name",
+		Options{GraphQL: true, TrimPrefix: "Level"},
+		"type T int\nconst (\n\tLevel1 T = 1\n)\n",
+		`invalid GraphQL name of Level1 of type T: "1"`,
+	},
+	{
+		"graphql_duplicate",
+		Options{GraphQL: true},
+		"type T int\nconst (\n\tNotFound T = 1\n\tNot_Found T = 2\n)\n",
+		"values with duplicate strings representations",
+	},
+	{
+		"bitmask_string",
+		Options{Bitmask: true},
+		"type T string\nconst (\n\tA T = \"a\"\n\tB T = \"b\"\n)\n",
+		"the underlying type is a string",
+	},
+	{
+		"errors_names",
+		Options{},
+		"type T int\nconst (\n\tA T = 1\n)\ntype ParseError struct{}\n",
+		"ParseError is declared by the package and by the generated file enum_errors.go",
+	},
+}
+
+func TestGenerateErrors(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	dir, err := ioutil.TempDir("", "stringer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, test := range generateErrorTests {
+		file := filepath.Join(dir, test.name+".go")
+		input := "package test\n" + test.input
+		if err := ioutil.WriteFile(file, []byte(input), 0644); err != nil {
+			t.Fatal(err)
+		}
+		src, testSrc, err := Generate([]string{file}, []string{"T"}, test.opts)
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.errstr) {
+			t.Errorf("%s: error %q does not contain %q", test.name, err, test.errstr)
+		}
+		if src != nil || testSrc != nil {
+			t.Errorf("%s: expected no output with error: %s", test.name, err)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	dir, err := ioutil.TempDir("", "stringer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "day.go")
+	if err := ioutil.WriteFile(file, []byte("package test\n"+day_in), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := GenerateOutput([]string{file}, []string{"Day"}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const header = "// Code generated by \"go-enum -type=Day\"; DO NOT EDIT."
+	if !strings.HasPrefix(string(out.Source), header) {
+		t.Errorf("missing header: %q", out.Source)
+	}
+	if !strings.Contains(string(out.Source), "func (i Day) String() string") {
+		t.Error("missing String method")
+	}
+	if !strings.Contains(string(out.Test), "func TestGeneratedEnum_Day(t *testing.T)") {
+		t.Error("missing test function")
+	}
+	if out.Package != "test" {
+		t.Errorf("got package: %q want: %q", out.Package, "test")
+	}
+	if string(out.Errors) != string(GenerateErrors("test")) {
+		t.Errorf("unexpected errors file: %q", out.Errors)
+	}
+	if out.Schema != nil {
+		t.Errorf("unexpected GraphQL schema: %q", out.Schema)
+	}
+
+	// The errors file of a previous run does not conflict with itself.
+	errorsFile := filepath.Join(dir, ErrorsFile)
+	if err := ioutil.WriteFile(errorsFile, out.Errors, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GenerateOutput([]string{file, errorsFile}, []string{"Day"}, Options{}); err != nil {
+		t.Errorf("regenerating with %s: %s", ErrorsFile, err)
+	}
+}
+
+func TestGenerateErrorsFile(t *testing.T) {
+	src := GenerateErrors("test")
+	formatted, err := format.Source(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(formatted) != string(src) {
+		t.Errorf("source is not formatted:\n%s", src)
+	}
+	if !strings.Contains(string(src), "\npackage test\n") {
+		t.Errorf("missing package clause: %q", src)
+	}
+
+	// The names checked for conflicts are those declared by the file.
+	f, err := parser.ParseFile(token.NewFileSet(), ErrorsFile, src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for name := range f.Scope.Objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	want := append([]string(nil), errorsNames...)
+	sort.Strings(want)
+	if strings.Join(names, " ") != strings.Join(want, " ") {
+		t.Errorf("got names: %q want: %q", names, want)
+	}
+}
+
+func TestGenerateGraphQL(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	dir, err := ioutil.TempDir("", "stringer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	const input = `package test
+type Status int
+const (
+	StatusOK Status = iota
+	StatusNotFound
+	// Deprecated: Use StatusNotFound instead.
+	StatusMissing
+)
+`
+	file := filepath.Join(dir, "status.go")
+	if err := ioutil.WriteFile(file, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		transform string
+		schema    string
+	}{
+		{"", "# Code generated by \"go-enum -type=Status\"; DO NOT EDIT.\n\n" +
+			"enum Status {\n" +
+			"  OK\n" +
+			"  NOT_FOUND\n" +
+			"  MISSING @deprecated(reason: \"Use StatusNotFound instead.\")\n" +
+			"}\n"},
+		{"none", "# Code generated by \"go-enum -type=Status\"; DO NOT EDIT.\n\n" +
+			"enum Status {\n" +
+			"  OK\n" +
+			"  NotFound\n" +
+			"  Missing @deprecated(reason: \"Use StatusNotFound instead.\")\n" +
+			"}\n"},
+	} {
+		opts := Options{TrimPrefix: "Status", GraphQL: true, GraphQLTransform: test.transform}
+		out, err := GenerateOutput([]string{file}, []string{"Status"}, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out.Schema) != test.schema {
+			t.Errorf("%q: got:\n%s\nwant:\n%s", test.transform, out.Schema, test.schema)
+		}
+		if !strings.Contains(string(out.Source), "func (i Status) MarshalGQL(w io.Writer)") {
+			t.Errorf("%q: missing MarshalGQL method", test.transform)
+		}
+	}
+}
+
+func TestGenerateLogValue(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	// The LogValue method requires a module that requires Go 1.21.
+	for _, test := range []struct {
+		goVersion string
+		logValue  bool
+	}{
+		{"1.20", false},
+		{"1.21", true},
+	} {
+		dir, err := ioutil.TempDir("", "stringer")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		gomod := "module test\n\ngo " + test.goVersion + "\n"
+		if err := ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "day.go"), []byte("package test\n"+day_in), 0644); err != nil {
+			t.Fatal(err)
+		}
+		// The package must be loaded from within its module.
+		if err := os.Chdir(dir); err != nil {
+			t.Fatal(err)
+		}
+		src, _, err := Generate(nil, []string{"Day"}, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok := strings.Contains(string(src), "func (i Day) LogValue() slog.Value"); ok != test.logValue {
+			t.Errorf("go %s: got LogValue method: %t want: %t", test.goVersion, ok, test.logValue)
+		}
+	}
+}
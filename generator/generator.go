@@ -0,0 +1,5328 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package generator generates String, Valid, MarshalText, UnmarshalText and
+// related methods for integer enum types. It implements the go-enum command,
+// see that command's documentation for a description of the generated code
+// and of the options.
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/token"
+	"go/types"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+
+	"golang.org/x/tools/go/packages"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+//
+// TODO:
+// 	1. Use the same Marshal* methods for all Run methods
+// 	2. rename "stringer" => "go-enum" (or something)
+//
+////////////////////////////////////////////////////////////////////////////////
+
+const generateMarshalers = true
+const generateTests = true
+
+// Arguments to format are:
+//	[1]: command line
+//	[2]: package name
+//	[3]: additional imports, one per line
+const testFileHeader = `
+// Code generated by "stringer %[1]s"; DO NOT EDIT.
+
+package %[2]s
+
+import (
+	"encoding"
+%[3]s	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+`
+
+// ErrorsFile is the name of the file, written to the directory of the
+// generated files, that declares the error types of the generated methods.
+const ErrorsFile = "enum_errors.go"
+
+// Argument to format is the package name.
+const errorsTemplate = `// Code generated by "go-enum"; DO NOT EDIT.
+
+package %s
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrInvalid is matched by the errors returned for values that are not
+	// one of the constants of their type.
+	ErrInvalid = errors.New("invalid value")
+
+	// ErrMalformed is matched by the errors returned for strings that cannot
+	// be parsed as a value.
+	ErrMalformed = errors.New("malformed value")
+)
+
+// An InvalidValueError is returned when a value that is not one of the
+// constants of its type is marshaled, or decoded by UnmarshalBinary.
+type InvalidValueError struct {
+	Type  string // The name of the type.
+	Value string // The value: an integer, or a quoted string for string types.
+}
+
+func (e *InvalidValueError) Error() string {
+	return "invalid " + e.Type + ": " + e.Value
+}
+
+// Is reports whether target is ErrInvalid.
+func (e *InvalidValueError) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+// A ParseError is returned when a string cannot be parsed as a value.
+type ParseError struct {
+	Type  string // The name of the type.
+	Input string // The string being parsed, or the binary data in hexadecimal.
+
+	names []string // The names that are parsed, shared by the errors of the type.
+}
+
+// Names returns a copy of the names that are parsed as values of the type,
+// if any.
+func (e *ParseError) Names() []string {
+	if e.names == nil {
+		return nil
+	}
+	return append([]string(nil), e.names...)
+}
+
+// Error returns the error message, which truncates long inputs and suggests
+// the names closest to the input, or lists the names if there are few.
+func (e *ParseError) Error() string {
+	msg := "malformed " + e.Type + ": "
+	if len(e.Input) <= 32 {
+		msg += strconv.Quote(e.Input)
+	} else {
+		msg += strconv.Quote(e.Input[0:29]) + "..."
+	}
+	if names := _enum_closestNames(e.Input, e.names); len(names) != 0 {
+		return msg + " (did you mean " + _enum_quoteNames(names, " or ") + "?)"
+	}
+	if len(e.names) != 0 && len(e.names) <= 10 {
+		return msg + " (valid values: " + _enum_quoteNames(e.names, ", ") + ")"
+	}
+	return msg
+}
+
+// Is reports whether target is ErrMalformed.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrMalformed
+}
+
+// _enum_closestNames returns up to three of the names closest to s, ignoring
+// case, if they are close enough for s to be a misspelling of them.
+func _enum_closestNames(s string, names []string) []string {
+	if len(s) > 32 {
+		return nil
+	}
+	s = strings.ToLower(s)
+	var closest []string
+	best := 2 // Misspellings are at most 2 edits away.
+	for _, name := range names {
+		d := _enum_editDistance(s, strings.ToLower(name))
+		if d >= len(name) || d > best {
+			continue
+		}
+		if d < best {
+			best = d
+			closest = closest[:0]
+		}
+		if len(closest) < 3 {
+			closest = append(closest, name)
+		}
+	}
+	return closest
+}
+
+// _enum_editDistance returns the Levenshtein distance between a and b.
+func _enum_editDistance(a, b string) int {
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 0; i < len(a); i++ {
+		prev := row[0]
+		row[0] = i + 1
+		for j := 0; j < len(b); j++ {
+			d := prev
+			if a[i] != b[j] {
+				d = 1 + _enum_min3(prev, row[j], row[j+1])
+			}
+			prev, row[j+1] = row[j+1], d
+		}
+	}
+	return row[len(b)]
+}
+
+func _enum_min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// _enum_quoteNames returns the quoted names joined by sep.
+func _enum_quoteNames(names []string, sep string) string {
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(strconv.Quote(name))
+	}
+	return b.String()
+}
+
+// _enum_foldRune returns the smallest rune that r equals ignoring case, so
+// two strings are equal under strings.EqualFold if and only if they are equal
+// after mapping their runes with it.
+func _enum_foldRune(r rune) rune {
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+`
+
+// errorsNames are the package-level names declared by errorsTemplate, which
+// the package of the types must not declare itself.
+var errorsNames = []string{
+	"ErrInvalid",
+	"ErrMalformed",
+	"InvalidValueError",
+	"ParseError",
+	"_enum_closestNames",
+	"_enum_editDistance",
+	"_enum_min3",
+	"_enum_quoteNames",
+	"_enum_foldRune",
+}
+
+// checkErrorsNames returns an error if the package declares one of the names
+// declared by the errors file in one of its other files. The errors file
+// itself is part of the package when it is regenerated.
+func (p *pkg) checkErrorsNames() error {
+	for _, name := range errorsNames {
+		obj := p.scope.Lookup(name)
+		if obj == nil {
+			continue
+		}
+		pos := p.fset.Position(obj.Pos())
+		if filepath.Base(pos.Filename) == ErrorsFile {
+			continue
+		}
+		return fmt.Errorf("%s: %s is declared by the package and by the generated file %s",
+			pos, name, ErrorsFile)
+	}
+	return nil
+}
+
+// Options control the code generated by Generate. Each option corresponds
+// to the go-enum flag of the same name.
+type Options struct {
+	TrimPrefix  string   // Trim the prefix from the generated constant names.
+	LineComment bool     // Use line comment text as printed text when present.
+	SQL         bool     // Generate database/sql Scanner and driver.Valuer methods.
+	Tags        []string // Build tags to apply when loading the package.
+	Bitmask     bool     // The constants are bit flags that may be combined.
+	NoCase      bool     // Parse the names of the constants ignoring case.
+	Values      bool     // Generate functions listing the values of the types.
+	JSON        bool     // Generate MarshalJSON and UnmarshalJSON methods.
+	Binary      bool     // Generate MarshalBinary, AppendBinary and UnmarshalBinary methods.
+	YAML        bool     // Generate MarshalYAML and UnmarshalYAML methods.
+	XML         bool     // Generate encoding/xml attribute and element marshalers.
+	Formatter   bool     // Generate fmt.Formatter and fmt.GoStringer methods.
+	GraphQL     bool     // Generate gqlgen MarshalGQL and UnmarshalGQL methods.
+
+	// Lookup is the strategy used by the unmarshal methods to look up the
+	// names of the constants: "switch", "map" or "phash" (a perfect hash
+	// table, which is slower than a map but built at generation time). The
+	// default, "auto", uses a switch for up to 32 names and a map for more.
+	Lookup string
+
+	// Sparse is the strategy used by String for values that are too sparse
+	// for the runs of names to be indexed directly: "map" (the default), a
+	// map built when the package is initialized, or "search", a binary search
+	// over static tables of the values and names.
+	Sparse string
+
+	// Transform is the transformation applied to the names of the constants
+	// after trimming the prefix: "snake" (not_found), "kebab" (not-found),
+	// "lower" (notfound), "upper" (NOT_FOUND), "title" (Not Found) or
+	// "words" (not found). Names taken from line comments are not transformed.
+	Transform string
+
+	// Exclude is a regular expression matching the names of constants to
+	// ignore, such as sentinels used for range checks. Constants marked with
+	// the enum:skip directive are ignored as well.
+	Exclude string
+
+	// ReplaceDeprecated makes MarshalText return the name of the replacement
+	// of a deprecated constant, the first constant of the type named by the
+	// "Deprecated:" paragraph of its doc comment.
+	ReplaceDeprecated bool
+
+	// Lenient makes MarshalText return the string returned by String for
+	// invalid values, "T(n)", and the unmarshal methods accept that form
+	// and bare integers, so that unknown values survive a round-trip.
+	Lenient bool
+
+	// Encoding is the format of the values in MarshalText and UnmarshalText,
+	// and so in JSON: "name" (the default), or "number" for the integer value,
+	// a JSON number. With "number,name" the names are also unmarshaled.
+	Encoding string
+
+	// GraphQLTransform is the transformation applied to the names of the
+	// constants, after trimming the prefix, to form the names of the values
+	// in the GraphQL schema: "upper" (NOT_FOUND, the default), "snake"
+	// (not_found), "lower" (notfound) or "none" (NotFound).
+	GraphQLTransform string
+
+	// Slog selects the LogValue method generated for log/slog, which logs the
+	// values by name: "name" returns the string returned by String, "group" a
+	// group of that name and the integer value, and "none" generates no
+	// method. The default, "auto", generates the "name" form if the module of
+	// the package requires Go 1.21 or later, when log/slog is available.
+	Slog string
+
+	// Command is the command line recorded in the "Code generated" comment
+	// at the top of the generated files. If empty, a command line with the
+	// -type flag set to the type names is used.
+	Command string
+}
+
+// Output holds the files generated by GenerateOutput.
+type Output struct {
+	Package string // Name of the package of the types.
+	Source  []byte // Formatted source of the generated methods.
+	Test    []byte // Formatted source of the test file.
+	Errors  []byte // Source of the file declaring the error types, named ErrorsFile.
+	Schema  []byte // GraphQL schema declaring the types, if the GraphQL option is set.
+}
+
+// GenerateOutput generates the methods of the named types, which must be
+// declared in the single package matched by the patterns, and returns the
+// generated files.
+//
+// The generated methods return the error types declared by Errors, which must
+// be written to the same package for the source to compile. It is an error
+// for the other files of the package to declare any of its names.
+//
+// If the generated code cannot be formatted, which signifies a bug in the
+// generator, the unformatted source is returned along with the error so that
+// it can be compiled to analyze the problem.
+func GenerateOutput(patterns, typeNames []string, opts Options) (*Output, error) {
+	g, err := generate(patterns, typeNames, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &Output{
+		Package: g.pkg.name,
+		Errors:  GenerateErrors(g.pkg.name),
+	}
+	if g.GraphQL {
+		out.Schema = g.sbuf.Bytes()
+	}
+
+	// Format the output.
+	out.Source, err = g.format()
+	if err != nil {
+		out.Test = g.tbuf.Bytes()
+		return out, err
+	}
+	if generateTests {
+		out.Test, err = g.formatTest()
+	}
+	return out, err
+}
+
+// Generate is like GenerateOutput but returns only the source of the
+// generated file and of its test file. The source compiles only with the
+// file returned by GenerateErrors for the package, which GenerateOutput
+// returns as well.
+func Generate(patterns, typeNames []string, opts Options) (src, testSrc []byte, err error) {
+	out, err := GenerateOutput(patterns, typeNames, opts)
+	if out == nil {
+		return nil, nil, err
+	}
+	return out.Source, out.Test, err
+}
+
+// generate checks the options, parses the package and generates the code of
+// the named types into the buffers of the returned generator.
+func generate(patterns, typeNames []string, opts Options) (*generator, error) {
+	if len(typeNames) == 0 {
+		return nil, errors.New("no type names specified")
+	}
+	if len(patterns) == 0 {
+		// Default: process whole package in current directory.
+		patterns = []string{"."}
+	}
+	g := &generator{Options: opts}
+	if g.SQL && !generateMarshalers {
+		return nil, errors.New("cannot generate SQL without Marshalers")
+	}
+	switch g.Lookup {
+	case "", lookupAuto, lookupSwitch:
+	case lookupMap, lookupPhash:
+		if g.Bitmask {
+			return nil, fmt.Errorf("lookup strategy %q cannot be used with bitmask types", g.Lookup)
+		}
+	default:
+		return nil, fmt.Errorf("invalid lookup strategy: %q", g.Lookup)
+	}
+	if g.Lenient && g.Bitmask {
+		return nil, errors.New("lenient parsing cannot be used with bitmask types")
+	}
+	switch g.Encoding {
+	case "", encodingName:
+	case encodingNumber, encodingNumberName:
+		if g.Bitmask {
+			return nil, fmt.Errorf("encoding %q cannot be used with bitmask types", g.Encoding)
+		}
+		if g.ReplaceDeprecated {
+			return nil, fmt.Errorf("encoding %q cannot be used to replace deprecated constants", g.Encoding)
+		}
+	default:
+		return nil, fmt.Errorf("invalid encoding: %q", g.Encoding)
+	}
+	switch g.Slog {
+	case "", slogAuto, slogName, slogGroup, slogNone:
+	default:
+		return nil, fmt.Errorf("invalid slog format: %q", g.Slog)
+	}
+	if g.GraphQL && g.Bitmask {
+		return nil, errors.New("GraphQL enums cannot be generated for bitmask types")
+	}
+	switch g.GraphQLTransform {
+	case "", graphqlUpper, graphqlSnake, graphqlLower, graphqlNone:
+	default:
+		return nil, fmt.Errorf("invalid GraphQL transform: %q", g.GraphQLTransform)
+	}
+	switch g.Sparse {
+	case "", sparseMap, sparseSearch:
+	default:
+		return nil, fmt.Errorf("invalid sparse strategy: %q", g.Sparse)
+	}
+	if g.Transform != "" && transforms[g.Transform] == nil {
+		return nil, fmt.Errorf("invalid transform: %q", g.Transform)
+	}
+	if g.Exclude != "" {
+		if _, err := regexp.Compile(g.Exclude); err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern: %s", err)
+		}
+	}
+	if err := g.parsePackage(patterns, opts.Tags); err != nil {
+		return nil, err
+	}
+	if err := g.pkg.checkErrorsNames(); err != nil {
+		return nil, err
+	}
+
+	command := opts.Command
+	if command == "" {
+		command = "-type=" + strings.Join(typeNames, ",")
+	}
+
+	// Print the header and package clause.
+	g.printf("// Code generated by \"go-enum %s\"; DO NOT EDIT.\n", command)
+	g.printf("\n")
+	g.printf("package %s", g.pkg.name)
+	g.printf("\n")
+	if g.SQL {
+		g.printf("import \"database/sql/driver\"\n") // Return value for Value() methods
+	}
+	if g.Binary {
+		g.printf("import \"encoding/binary\"\n") // Used by binary marshalers.
+		g.printf("import \"encoding/hex\"\n")    // Used by binary marshalers for errors.
+	}
+	if g.JSON || g.numberEncoding() {
+		g.printf("import \"encoding/json\"\n") // Used to unmarshal JSON strings.
+	}
+	if g.XML {
+		g.printf("import \"encoding/xml\"\n") // Used by XML marshalers.
+	}
+	if g.logValue() != "" {
+		g.printf("import \"log/slog\"\n") // Used by LogValue methods.
+	}
+	if g.SQL || g.Formatter || g.GraphQL {
+		g.printf("import \"fmt\"\n") // Used by sql and GraphQL methods for errors and by Format methods.
+	}
+	if g.GraphQL {
+		g.printf("import \"io\"\n") // Used by MarshalGQL methods.
+	}
+	g.printf("import \"strconv\"\n") // Used by all methods.
+	if g.NoCase {
+		g.printf("import \"strings\"\n") // Used by case-insensitive unmarshal methods.
+	}
+
+	// Print the header for the test file
+	testImports := ""
+	if g.Binary {
+		testImports += "\t\"encoding/binary\"\n" // Used to encode invalid values.
+	}
+	if g.XML {
+		testImports += "\t\"encoding/xml\"\n"
+	}
+	if g.logValue() != "" {
+		testImports += "\t\"log/slog\"\n"
+	}
+	g.tprintf(testFileHeader, command, g.pkg.name, testImports)
+
+	// Print the header of the GraphQL schema.
+	if g.GraphQL {
+		fmt.Fprintf(&g.sbuf, "# Code generated by \"go-enum %s\"; DO NOT EDIT.\n", command)
+	}
+
+	// Run generate for each type.
+	for _, typeName := range typeNames {
+		if err := g.generate(typeName); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// GenerateErrors returns the source of the file declaring the error types
+// returned by the generated methods in the named package. The source does not
+// depend on the types, so every run of go-enum in a package writes the same
+// file, named ErrorsFile.
+func GenerateErrors(pkgName string) []byte {
+	return []byte(fmt.Sprintf(errorsTemplate, pkgName))
+}
+
+// generator holds the state of the analysis. Primarily used to buffer
+// the output for format.Source.
+type generator struct {
+	buf  bytes.Buffer // Accumulated output.
+	tbuf bytes.Buffer // Accumulated test output.
+	sbuf bytes.Buffer // Accumulated GraphQL schema.
+	pkg  *pkg         // Package we are scanning.
+
+	// Whether the values of the type being generated have names in the
+	// text or SQL format that differ from the names returned by String.
+	textNames bool
+	sqlNames  bool
+
+	// The constant that unknown names are unmarshaled as, marked with the
+	// enum:default directive, or empty if unknown names are an error.
+	fallback string
+
+	Options
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) tprintf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.tbuf, format, args...)
+}
+
+// file holds a single parsed file and associated data.
+type file struct {
+	pkg  *pkg      // Package to which this file belongs.
+	file *ast.File // Parsed AST.
+	// These fields are reset for each type being generated.
+	typeName string  // Name of the constant type.
+	values   []value // Accumulator for constant values of that type.
+	excluded []value // Accumulator for the excluded constants of that type.
+	err      error   // First error encountered while walking the file.
+
+	trimPrefix  string
+	transform   func(string) string // Transformation of the trimmed names, or nil.
+	exclude     *regexp.Regexp      // Names of the constants to exclude, or nil.
+	lineComment bool
+	sql         bool
+}
+
+type pkg struct {
+	name      string
+	fset      *token.FileSet
+	defs      map[*ast.Ident]types.Object
+	uses      map[*ast.Ident]types.Object
+	scope     *types.Scope // Scope of the package-level names.
+	files     []*file
+	goVersion string // Go version required by the module, if known.
+}
+
+// parsePackage analyzes the single package constructed from the patterns and tags.
+func (g *generator) parsePackage(patterns []string, tags []string) error {
+	cfg := &packages.Config{
+		Mode: packages.LoadSyntax | packages.NeedModule,
+		// TODO: Need to think about constants in test files. Maybe write type_string_test.go
+		// in a separate pass? For later.
+		Tests:      false,
+		BuildFlags: []string{fmt.Sprintf("-tags=%s", strings.Join(tags, " "))},
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) != 1 {
+		return fmt.Errorf("error: %d packages found", len(pkgs))
+	}
+	g.addPackage(pkgs[0])
+	return nil
+}
+
+// addPackage adds a type checked package and its syntax files to the generator.
+func (g *generator) addPackage(p *packages.Package) {
+	var exclude *regexp.Regexp
+	if g.Exclude != "" {
+		// Generate validates the pattern.
+		exclude = regexp.MustCompile(g.Exclude)
+	}
+	g.pkg = &pkg{
+		name:  p.Name,
+		fset:  p.Fset,
+		defs:  p.TypesInfo.Defs,
+		uses:  p.TypesInfo.Uses,
+		scope: p.Types.Scope(),
+		files: make([]*file, len(p.Syntax)),
+	}
+	if p.Module != nil {
+		g.pkg.goVersion = p.Module.GoVersion
+	}
+
+	for i, syntax := range p.Syntax {
+		g.pkg.files[i] = &file{
+			file:        syntax,
+			pkg:         g.pkg,
+			trimPrefix:  g.TrimPrefix,
+			transform:   transforms[g.Transform],
+			exclude:     exclude,
+			lineComment: g.LineComment,
+		}
+	}
+}
+
+// writeConstantChecks generates code that will fail if the constants change value.
+func (g *generator) writeConstantChecks(typeName string, values []value) {
+	// If testing is enabled write to these checks to the test buffer,
+	// otherwise we won't be able to achieve 100% test coverage.
+	w := &g.buf
+	if generateTests {
+		w = &g.tbuf
+	}
+	// Generate code that will fail if the constants change value.
+	fmt.Fprintf(w, "func _() {\n")
+	if values[0].isString() {
+		// There is no arithmetic on strings, instead a map literal with
+		// duplicate keys fails to compile.
+		fmt.Fprintf(w, "\t// A \"duplicate key\" compiler error signifies that the constant values have changed.\n")
+		fmt.Fprintf(w, "\t// Re-run the stringer command to generate them again.\n")
+		for _, v := range values {
+			fmt.Fprintf(w, "\t_ = map[bool]int{false: 0, %s == %s: 1}\n", v.originalName, v.str)
+		}
+		fmt.Fprintf(w, "}\n")
+		return
+	}
+	fmt.Fprintf(w, "\t// An \"invalid array index\" compiler error signifies that the constant values have changed.\n")
+	fmt.Fprintf(w, "\t// Re-run the stringer command to generate them again.\n")
+	fmt.Fprintf(w, "\tvar x [1]struct{}\n")
+	for _, v := range values {
+		fmt.Fprintf(w, "\t_ = x[%s - %s]\n", v.originalName, v.str)
+	}
+	fmt.Fprintf(w, "}\n")
+}
+
+// generate produces the String method for the named type.
+func (g *generator) generate(typeName string) error {
+	values := make([]value, 0, 100)
+	var excluded []value
+	for _, file := range g.pkg.files {
+		// Set the state for this run of the walker.
+		file.typeName = typeName
+		file.values = nil
+		file.excluded = nil
+		file.err = nil
+		if file.file != nil {
+			ast.Inspect(file.file, file.genDecl)
+			if file.err != nil {
+				return file.err
+			}
+			values = append(values, file.values...)
+			excluded = append(excluded, file.excluded...)
+		}
+	}
+
+	if len(values) == 0 {
+		return fmt.Errorf("no values defined for type %s", typeName)
+	}
+	// Generate code that will fail if the constants change value.
+	g.writeConstantChecks(typeName, values)
+
+	values, err := mergeAliases(typeName, values)
+	if err != nil {
+		return err
+	}
+	if g.ReplaceDeprecated {
+		n := findReplacements(values)
+		if n > 0 && (g.Bitmask || values[0].isString()) {
+			return fmt.Errorf("cannot replace the deprecated constants of type %s: "+
+				"only supported for integer types that are not bitmasks", typeName)
+		}
+	}
+	g.textNames = hasFormatNames(values, (*value).marshalName)
+	if g.textNames && g.numberEncoding() {
+		return fmt.Errorf("json names cannot be used with the number encoding of type %s", typeName)
+	}
+	// The generic Scan method parses []byte with UnmarshalText, so the
+	// names in the SQL format are parsed separately if those in the text
+	// format differ.
+	g.sqlNames = g.SQL && (g.textNames || g.numberEncoding() || hasFormatNames(values, (*value).sqlName))
+	if g.Bitmask && (g.textNames || g.sqlNames) {
+		return fmt.Errorf("json and sql names cannot be used with bitmask type %s", typeName)
+	}
+	if g.fallback, err = findFallback(typeName, values); err != nil {
+		return err
+	}
+	if g.Bitmask && g.fallback != "" {
+		return fmt.Errorf("the default directive cannot be used with bitmask type %s", typeName)
+	}
+	if generateMarshalers {
+		formats := [][]value{
+			values,
+			withFormatNames(values, (*value).textName),
+			withFormatNames(values, (*value).sqlName),
+		}
+		if g.GraphQL {
+			if err := checkGraphQLNames(typeName, withFormatNames(values, g.graphqlName)); err != nil {
+				return err
+			}
+			formats = append(formats, withFormatNames(values, g.graphqlName))
+		}
+		for _, names := range formats {
+			if err := checkForDuplicateStrings(typeName, names); err != nil {
+				return err
+			}
+			if g.NoCase {
+				if err := checkForFoldedDuplicateStrings(typeName, names); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if generateMarshalers {
+		g.buildNames(values, typeName)
+	}
+	if values[0].isString() {
+		return g.generateString(values, excluded, typeName)
+	}
+
+	if g.Bitmask {
+		flags, err := g.buildBitmask(values, typeName)
+		if err != nil {
+			return err
+		}
+		if generateTests {
+			g.buildBitmaskTests(flags, typeName)
+		}
+		return nil
+	}
+
+	runs := splitIntoRuns(values)
+	// The decision of which pattern to use depends on the number of
+	// runs in the numbers. If there's only one, it's easy. For more than
+	// one, there's a tradeoff between complexity and size of the data
+	// and code vs. the simplicity of a map. A map takes more space,
+	// but so does the code. The decision here (crossover at 10) is
+	// arbitrary, but considers that for large numbers of runs the cost
+	// of the linear scan in the switch might become important, and
+	// rather than use yet another algorithm such as binary search,
+	// we punt and use a map, unless the Sparse option asks for a binary
+	// search. In any case, the likelihood of a map being necessary for
+	// any realistic example other than bitmasks is very low. And bitmasks
+	// probably deserve their own analysis, to be done some other day.
+	multipleRuns := false
+	switch {
+	case len(runs) == 1:
+		g.buildOneRun(runs, typeName)
+	case len(runs) <= 10:
+		multipleRuns = true
+		g.buildMultipleRuns(runs, typeName)
+	case g.Sparse == sparseSearch:
+		g.buildSearch(runs, typeName)
+	default:
+		g.buildMap(runs, typeName)
+	}
+	if generateMarshalers {
+		if g.Lenient && !g.textNames && !g.numberEncoding() {
+			g.printf(stringLenientMarshal, typeName)
+		}
+		if err := g.buildUnmarshalers(runs, typeName, multipleRuns); err != nil {
+			return err
+		}
+		if g.textNames {
+			g.buildTextNames(values, typeName)
+		}
+		if g.numberEncoding() {
+			g.buildNumberEncoding(values, typeName)
+		} else if g.JSON {
+			g.buildJSON(values, typeName)
+		}
+		if g.Binary {
+			g.buildBinary(values, typeName)
+		}
+		if g.YAML {
+			g.buildYAML(values, typeName)
+		}
+		if g.XML {
+			g.printf(stringXML, typeName)
+		}
+		if g.GraphQL {
+			g.buildGraphQL(values, typeName)
+		}
+	}
+	if g.sqlNames {
+		g.buildSQLNames(values, typeName)
+	}
+	g.buildLogValue(values, typeName)
+	if g.Formatter {
+		g.buildFormat(values, typeName)
+	}
+	if g.Values {
+		g.buildValues(runs, typeName)
+	}
+	if generateTests {
+		g.buildTests(runs, excluded, typeName)
+	}
+	return nil
+}
+
+// mergeAliases merges the constants that share a value into the primary
+// constant for the value, the one marked with the enum:primary directive or
+// else the first one declared that is not deprecated. The others become its
+// aliases, which are parsed but never returned by String.
+func mergeAliases(typeName string, values []value) ([]value, error) {
+	key := func(v *value) string {
+		if v.isString() {
+			return v.name
+		}
+		return strconv.FormatUint(v.value, 10)
+	}
+	merged := make([]value, 0, len(values))
+	index := make(map[string]int, len(values))
+	for _, v := range values {
+		i, ok := index[key(&v)]
+		if !ok {
+			index[key(&v)] = len(merged)
+			merged = append(merged, v)
+			continue
+		}
+		primary, alias := merged[i], v
+		if v.primary {
+			if primary.primary {
+				return nil, fmt.Errorf("cannot generate marshal/unmarshal methods for type: %s "+
+					"found multiple primary constants with the value %s: %s and %s",
+					typeName, v.str, primary.originalName, v.originalName)
+			}
+			primary, alias = v, merged[i]
+		} else if !primary.primary && primary.deprecated && !v.deprecated {
+			// Prefer a name that is not deprecated.
+			primary, alias = v, merged[i]
+		}
+		// The aliases of the alias, given by the enum:alias directive or
+		// merged before, become aliases of the primary constant.
+		aliases := alias.aliases
+		alias.aliases = nil
+		primary.aliases = append(append(primary.aliases, alias), aliases...)
+		merged[i] = primary
+	}
+	return merged, nil
+}
+
+// checkForDuplicateStrings checks for values that have duplicate string forms
+// which is possible with the -linecomment flag and makes generating
+// marshal/unmarshal methods impossible.
+func checkForDuplicateStrings(typeName string, values []value) error {
+	dupes := false
+	seen := make(map[string][]string, len(values))
+	for _, v := range values {
+		for _, k := range v.keys() {
+			seen[k.name] = append(seen[k.name], k.originalName)
+			dupes = dupes || len(seen[k.name]) > 1
+		}
+	}
+	if !dupes {
+		return nil
+	}
+	var buf bytes.Buffer
+	for name, origNames := range seen {
+		if len(origNames) == 1 {
+			continue
+		}
+		if buf.Len() != 0 {
+			buf.WriteString("; ")
+		}
+		fmt.Fprintf(&buf, "%s == %s", origNames, name)
+	}
+	return fmt.Errorf("cannot generate marshal/unmarshal methods for type: %s found "+
+		"values with duplicate strings representations: %s",
+		typeName, &buf)
+}
+
+// checkGraphQLNames checks that the names of the values, their names in the
+// GraphQL schema, are valid names of GraphQL enum values.
+func checkGraphQLNames(typeName string, values []value) error {
+	for _, v := range values {
+		for _, k := range v.keys() {
+			if !isGraphQLName(k.name) {
+				return fmt.Errorf("invalid GraphQL name of %s of type %s: %q", k.originalName, typeName, k.name)
+			}
+		}
+	}
+	return nil
+}
+
+// isGraphQLName reports whether s is a valid name of a GraphQL enum value: a
+// letter or underscore followed by letters, digits and underscores, other than
+// true, false and null.
+func isGraphQLName(s string) bool {
+	if s == "" || s == "true" || s == "false" || s == "null" {
+		return false
+	}
+	for i, c := range s {
+		if !(c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || i > 0 && '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// splitIntoRuns breaks the values into runs of contiguous sequences.
+// For example, given 1,2,3,5,6,7 it returns {1,2,3},{5,6,7}.
+// The input slice is known to be non-empty.
+func splitIntoRuns(values []value) [][]value {
+	// We use stable sort so the lexically first name is chosen for equal elements.
+	sort.Stable(byValue(values))
+	// Remove duplicates. Stable sort has put the one we want to print first,
+	// so use that one. The String method won't care about which named constant
+	// was the argument, so the first name for the given value is the only one to keep.
+	// We need to do this because identical values would cause the switch or map
+	// to fail to compile.
+	j := 1
+	for i := 1; i < len(values); i++ {
+		if values[i].value != values[i-1].value {
+			values[j] = values[i]
+			j++
+		}
+	}
+	values = values[:j]
+	runs := make([][]value, 0, 10)
+	for len(values) > 0 {
+		// One contiguous sequence per outer loop.
+		i := 1
+		for i < len(values) && values[i].value == values[i-1].value+1 {
+			i++
+		}
+		runs = append(runs, values[:i])
+		values = values[i:]
+	}
+	return runs
+}
+
+// format returns the gofmt-ed contents of the generator's buffer.
+func (g *generator) format() ([]byte, error) {
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		// Should never happen, but can arise when developing this code.
+		// The user can compile the output to see the error.
+		return g.buf.Bytes(), fmt.Errorf("internal error: invalid Go generated: %s", err)
+	}
+	return src, nil
+}
+
+// formatTest returns the gofmt-ed contents of the generator's test buffer.
+func (g *generator) formatTest() ([]byte, error) {
+	src, err := format.Source(g.tbuf.Bytes())
+	if err != nil {
+		return g.tbuf.Bytes(), fmt.Errorf("internal error: invalid Go generated (test files): %s", err)
+	}
+	return src, nil
+}
+
+// value represents a declared constant.
+type value struct {
+	originalName string // The name of the constant.
+	name         string // The name with trimmed prefix.
+	// The value is stored as a bit pattern alone. The boolean tells us
+	// whether to interpret it as an int64 or a uint64; the only place
+	// this matters is when sorting.
+	// Much of the time the str field is all we need; it is printed
+	// by value.String.
+	value  uint64          // Will be converted to int64 when needed.
+	signed bool            // Whether the constant is a signed type.
+	str    string          // The string representation given by the "go/constant" package.
+	kind   types.BasicKind // Underlying type, used when generating tests
+
+	primary  bool    // Marked with the enum:primary directive.
+	fallback bool    // Marked with the enum:default directive.
+	aliases  []value // Other constants with the same value, see mergeAliases.
+
+	// Names of the value in the text (MarshalText and UnmarshalText) and SQL
+	// formats, set if the line comment is tag-style, see parseTags. If empty,
+	// the name is used.
+	text string
+	sql  string
+
+	deprecated  bool   // The doc comment has a "Deprecated:" paragraph.
+	deprecation string // The text of the paragraph, see deprecation.
+	replacement *value // Replacement of a deprecated value, see findReplacements.
+}
+
+// textName returns the name of the value in the text format.
+func (v *value) textName() string {
+	if v.text != "" {
+		return v.text
+	}
+	return v.name
+}
+
+// marshalName returns the name returned by MarshalText, which is the name of
+// the replacement of a deprecated value if there is one.
+func (v *value) marshalName() string {
+	if v.replacement != nil {
+		return v.replacement.textName()
+	}
+	return v.textName()
+}
+
+// sqlName returns the name of the value in the SQL format.
+func (v *value) sqlName() string {
+	if v.sql != "" {
+		return v.sql
+	}
+	return v.name
+}
+
+// isString reports whether the underlying type of the constant is a string,
+// in which case the value is its name and the value field is unused.
+func (v *value) isString() bool {
+	return v.kind == types.String
+}
+
+func (v *value) String() string {
+	return v.str
+}
+
+// keys returns v followed by its aliases, omitting those whose names are the
+// same as one before them. The names are the strings that are parsed as v.
+func (v *value) keys() []value {
+	keys := []value{*v}
+Aliases:
+	for _, a := range v.aliases {
+		for _, k := range keys {
+			if k.name == a.name {
+				continue Aliases
+			}
+		}
+		keys = append(keys, a)
+	}
+	return keys
+}
+
+// byValue lets us sort the constants into increasing order.
+// We take care in the Less method to sort in signed or unsigned order,
+// as appropriate.
+type byValue []value
+
+func (b byValue) Len() int      { return len(b) }
+func (b byValue) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b byValue) Less(i, j int) bool {
+	if b[i].signed {
+		return int64(b[i].value) < int64(b[j].value)
+	}
+	return b[i].value < b[j].value
+}
+
+// genDecl processes one declaration clause.
+func (f *file) genDecl(node ast.Node) bool {
+	if f.err != nil {
+		return false
+	}
+	decl, ok := node.(*ast.GenDecl)
+	if !ok || decl.Tok != token.CONST {
+		// We only care about const declarations.
+		return true
+	}
+	// The name of the type of the constants we are declaring.
+	// Can change if this is a multi-element declaration.
+	typ := ""
+	// Loop over the elements of the declaration. Each element is a ValueSpec:
+	// a list of names possibly followed by a type, possibly followed by values.
+	// If the type and value are both missing, we carry down the type (and value,
+	// but the "go/types" package takes care of that).
+	for _, spec := range decl.Specs {
+		vspec := spec.(*ast.ValueSpec) // Guaranteed to succeed as this is CONST.
+		if vspec.Type == nil && len(vspec.Values) > 0 {
+			// "X = 1". With no type but a value. If the constant is untyped,
+			// skip this vspec and reset the remembered type.
+			typ = ""
+
+			// If this is a simple type conversion, remember the type.
+			// We don't mind if this is actually a call; a qualified call won't
+			// be matched (that will be SelectorExpr, not Ident), and only unusual
+			// situations will result in a function call that appears to be
+			// a type conversion.
+			ce, ok := vspec.Values[0].(*ast.CallExpr)
+			if !ok {
+				// "X = Y". If Y is a constant of a type declared in the
+				// package, in any of its files, X is an alias such as
+				// "Acetaminophen = Paracetamol". Other expressions, such as
+				// the sentinel "numDays = Wednesday + 1", are skipped.
+				id, ok := vspec.Values[0].(*ast.Ident)
+				if !ok {
+					continue
+				}
+				obj, ok := f.pkg.uses[id].(*types.Const)
+				if !ok {
+					continue
+				}
+				typ = localTypeName(obj)
+				if typ == "" {
+					continue
+				}
+			} else if id, ok := ce.Fun.(*ast.Ident); ok {
+				typ = id.Name
+			} else {
+				continue
+			}
+		}
+		if vspec.Type != nil {
+			// "X T". We have a type. Remember it.
+			ident, ok := vspec.Type.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			typ = ident.Name
+		}
+		if typ != f.typeName {
+			// This is not the type we're looking for.
+			continue
+		}
+		// We now have a list of names (from one line of source code) all being
+		// declared with the desired type.
+		// Grab their names and actual values and store them in f.values.
+		doc := vspec.Doc
+		if doc == nil && !decl.Lparen.IsValid() {
+			// The doc comment of an unparenthesized declaration
+			// belongs to the declaration.
+			doc = decl.Doc
+		}
+		dirs, err := parseDirectives(doc, vspec.Comment)
+		if err != nil {
+			f.err = fmt.Errorf("%s: %s", f.pkg.fset.Position(vspec.Pos()), err)
+			return false
+		}
+		text, deprecated := deprecation(doc)
+		for _, name := range vspec.Names {
+			if name.Name == "_" {
+				continue
+			}
+			add := &f.values
+			if dirs.skip || f.exclude != nil && f.exclude.MatchString(name.Name) {
+				// The value is still needed by the tests.
+				add = &f.excluded
+			}
+			// This dance lets the type checker find the values for us. It's a
+			// bit tricky: look up the object declared by the name, find its
+			// types.Const, and extract its value.
+			obj, ok := f.pkg.defs[name]
+			if !ok {
+				f.err = fmt.Errorf("no value for constant %s", name)
+				return false
+			}
+			info := obj.Type().Underlying().(*types.Basic).Info()
+			kind := obj.Type().Underlying().(*types.Basic).Kind()
+			val := obj.(*types.Const).Val() // Guaranteed to succeed as this is CONST.
+			if info&types.IsString != 0 {
+				// The string is both the value and the name of the constant.
+				str := constant.StringVal(val)
+				*add = append(*add, dirs.withAliases(value{
+					originalName: name.Name,
+					name:         str,
+					str:          strconv.Quote(str),
+					kind:         kind,
+					primary:      dirs.primary,
+					fallback:     dirs.fallback,
+					deprecated:   deprecated,
+					deprecation:  text,
+				}))
+				continue
+			}
+			if info&types.IsInteger == 0 {
+				f.err = fmt.Errorf("can't handle non-integer constant type %s", typ)
+				return false
+			}
+			if val.Kind() != constant.Int {
+				f.err = fmt.Errorf("can't happen: constant is not an integer %s", name)
+				return false
+			}
+			i64, isInt := constant.Int64Val(val)
+			u64, isUint := constant.Uint64Val(val)
+			if !isInt && !isUint {
+				f.err = fmt.Errorf("internal error: value of %s is not an integer: %s", name, val.String())
+				return false
+			}
+			if !isInt {
+				u64 = uint64(i64)
+			}
+			v := value{
+				originalName: name.Name,
+				value:        u64,
+				signed:       info&types.IsUnsigned == 0,
+				str:          val.String(),
+				kind:         kind,
+				primary:      dirs.primary,
+				fallback:     dirs.fallback,
+				deprecated:   deprecated,
+				deprecation:  text,
+			}
+			// Text omits directives, so a line comment that is only a
+			// directive does not rename the constant. The check for a
+			// directive is for those with a space after the slashes.
+			c := vspec.Comment
+			tags, isTag := parseTags(c)
+			isTag = isTag && f.lineComment
+			if isTag {
+				if key := unknownTag(tags); key != "" {
+					f.err = fmt.Errorf("%s: unknown key %q in the line comment of %s",
+						f.pkg.fset.Position(vspec.Pos()), key, name.Name)
+					return false
+				}
+			}
+			if !isTag && f.lineComment && c != nil && len(c.List) == 1 && strings.TrimSpace(c.Text()) != "" &&
+				!isDirective(c.List[0]) {
+				v.name = strings.TrimSpace(c.Text())
+			} else {
+				v.name = strings.TrimPrefix(v.originalName, f.trimPrefix)
+				if f.transform != nil {
+					v.name = f.transform(v.name)
+				}
+			}
+			if isTag {
+				// A format without a key uses the name of the constant.
+				v.text, v.sql = v.name, v.name
+				if name := tags["display"]; name != "" {
+					v.name = name
+				}
+				if name := tags["json"]; name != "" {
+					v.text = name
+				}
+				if name := tags["sql"]; name != "" {
+					v.sql = name
+				}
+			}
+			*add = append(*add, dirs.withAliases(v))
+		}
+	}
+	return false
+}
+
+// transforms maps the values of the Transform option to their functions.
+var transforms = map[string]func(string) string{
+	"snake": func(s string) string { return strings.ToLower(strings.Join(splitWords(s), "_")) },
+	"kebab": func(s string) string { return strings.ToLower(strings.Join(splitWords(s), "-")) },
+	"lower": strings.ToLower,
+	"upper": func(s string) string { return strings.ToUpper(strings.Join(splitWords(s), "_")) },
+	"title": func(s string) string {
+		words := splitWords(s)
+		for i, w := range words {
+			r, n := utf8.DecodeRuneInString(w)
+			words[i] = string(unicode.ToUpper(r)) + w[n:]
+		}
+		return strings.Join(words, " ")
+	},
+	"words": func(s string) string { return strings.ToLower(strings.Join(splitWords(s), " ")) },
+}
+
+// splitWords splits a name into words at underscores, at the change from a
+// lower case letter or a digit to an upper case letter, and before the last
+// letter of a run of upper case letters that is followed by a lower case
+// letter, so that "HTTPStatusNotFound" becomes "HTTP", "Status", "Not" and
+// "Found". Digits belong to the word that precedes them.
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := 0
+	for i, r := range runes {
+		switch {
+		case r == '_':
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+		case i > start && unicode.IsUpper(r):
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// deprecation returns the text of the paragraph of the doc comment that
+// starts with "Deprecated:", the convention for marking deprecated
+// identifiers, and reports whether there is one.
+func deprecation(doc *ast.CommentGroup) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+	for _, p := range strings.Split(doc.Text(), "\n\n") {
+		if strings.HasPrefix(p, "Deprecated:") {
+			return strings.TrimSpace(p[len("Deprecated:"):]), true
+		}
+	}
+	return "", false
+}
+
+// localTypeName returns the name of the type of the object if it is a named
+// type declared in the package of the object, otherwise it returns "".
+func localTypeName(obj types.Object) string {
+	if obj == nil {
+		return ""
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.Obj().Pkg() != obj.Pkg() {
+		return ""
+	}
+	return named.Obj().Name()
+}
+
+// directivePrefix is the prefix of the comment directives that control the
+// code generated for a constant, for example:
+//
+//	//enum:primary
+//	Paracetamol Pill = 3
+//
+// A directive with arguments separates them from its name with "=":
+//
+//	Monday Day = iota //enum:alias=mon,lundi
+const directivePrefix = "//enum:"
+
+// isDirective reports whether the comment is a directive. A space is allowed
+// between the slashes and the prefix, as in "// enum:alias=mon".
+func isDirective(c *ast.Comment) bool {
+	return strings.HasPrefix(c.Text, directivePrefix) ||
+		strings.HasPrefix(c.Text, "// "+directivePrefix[2:])
+}
+
+// directives holds the comment directives of a constant declaration.
+type directives struct {
+	// primary marks the constant as the one whose name is used by String
+	// when several constants share its value.
+	primary bool
+
+	// aliases are the additional names parsed as the constant, given by
+	// the enum:alias directive.
+	aliases []string
+
+	// skip excludes the constant, which is neither printed nor parsed.
+	skip bool
+
+	// fallback marks the constant as the value of the names that are not
+	// recognized by the unmarshal methods, given by the enum:default
+	// directive.
+	fallback bool
+}
+
+// withAliases returns v with the names given by the enum:alias directive
+// added to its aliases.
+func (d *directives) withAliases(v value) value {
+	for _, name := range d.aliases {
+		a := v
+		a.name = name
+		a.primary, a.fallback = false, false
+		a.text, a.sql = "", ""
+		v.aliases = append(v.aliases, a)
+	}
+	return v
+}
+
+// parseDirectives parses the comment directives of a constant declaration
+// from its doc and line comments. Like other Go directives they must start
+// at the beginning of the comment, see isDirective.
+func parseDirectives(groups ...*ast.CommentGroup) (directives, error) {
+	var dirs directives
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+		for _, c := range g.List {
+			if !isDirective(c) {
+				continue
+			}
+			text := strings.TrimPrefix(strings.TrimPrefix(c.Text, "// "), "//")
+			name := strings.TrimSpace(text[len(directivePrefix)-2:])
+			var arg string
+			if i := strings.IndexByte(name, '='); i >= 0 {
+				name, arg = name[:i], name[i+1:]
+			}
+			switch name {
+			case "primary":
+				if arg != "" {
+					return dirs, fmt.Errorf("unexpected argument: %s", c.Text)
+				}
+				dirs.primary = true
+			case "skip":
+				if arg != "" {
+					return dirs, fmt.Errorf("unexpected argument: %s", c.Text)
+				}
+				dirs.skip = true
+			case "default":
+				if arg != "" {
+					return dirs, fmt.Errorf("unexpected argument: %s", c.Text)
+				}
+				dirs.fallback = true
+			case "alias":
+				for _, alias := range strings.Split(arg, ",") {
+					alias = strings.TrimSpace(alias)
+					if alias == "" {
+						return dirs, fmt.Errorf("empty alias name: %s", c.Text)
+					}
+					dirs.aliases = append(dirs.aliases, alias)
+				}
+			default:
+				return dirs, fmt.Errorf("unknown directive: %s", c.Text)
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// parseTags parses a line comment written in the syntax of a struct tag, such
+// as
+//
+//	StatusOK Status = 200 // json:"ok" sql:"OK" display:"All good"
+//
+// and returns the values of its keys. It reports false if the comment is not
+// a single line comment consisting only of key:"value" pairs.
+func parseTags(c *ast.CommentGroup) (map[string]string, bool) {
+	if c == nil || len(c.List) != 1 {
+		return nil, false
+	}
+	tag := strings.TrimSpace(c.Text())
+	if tag == "" {
+		return nil, false
+	}
+	tags := make(map[string]string)
+	for tag != "" {
+		// The key is a non-empty run of characters other than spaces,
+		// quotes, colons and control characters, as in reflect.StructTag.
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return nil, false
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		// Scan the quoted string to find the value.
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return nil, false
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			return nil, false
+		}
+		if _, dup := tags[key]; dup {
+			return nil, false
+		}
+		tags[key] = value
+		tag = strings.TrimLeft(tag[i+1:], " ")
+	}
+	return tags, true
+}
+
+// unknownTag returns the first key, in sorted order, of tags that is not one
+// of json, sql and display, or "" if there is none.
+func unknownTag(tags map[string]string) string {
+	var keys []string
+	for key := range tags {
+		switch key {
+		case "json", "sql", "display":
+		default:
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	return keys[0]
+}
+
+// findReplacements sets the replacement of each deprecated value to the first
+// value named in its "Deprecated:" paragraph by one of its constants, if that
+// value is not deprecated, and returns the number of replacements found.
+func findReplacements(values []value) int {
+	index := make(map[string]int)
+	for i, v := range values {
+		index[v.originalName] = i
+		for _, a := range v.aliases {
+			index[a.originalName] = i
+		}
+	}
+	n := 0
+	for i := range values {
+		v := &values[i]
+		if !v.deprecated {
+			continue
+		}
+		words := strings.FieldsFunc(v.deprecation, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+		})
+		for _, w := range words {
+			if j, ok := index[w]; ok && j != i && !values[j].deprecated {
+				r := values[j]
+				v.replacement = &r
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+// findFallback returns the name of the constant marked with the enum:default
+// directive, or an empty string if there is none.
+func findFallback(typeName string, values []value) (string, error) {
+	var fallback string
+	for _, v := range values {
+		for _, k := range append([]value{v}, v.aliases...) {
+			if !k.fallback {
+				continue
+			}
+			if fallback != "" {
+				return "", fmt.Errorf("cannot generate marshal/unmarshal methods for type: %s "+
+					"found multiple default constants: %s and %s", typeName, fallback, k.originalName)
+			}
+			fallback = k.originalName
+		}
+	}
+	return fallback, nil
+}
+
+// hasFormatNames reports whether any of the values or their aliases has a
+// name in a format, as returned by name, that differs from its name.
+func hasFormatNames(values []value, name func(*value) string) bool {
+	for i := range values {
+		for _, v := range append([]value{values[i]}, values[i].aliases...) {
+			if name(&v) != v.name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withFormatNames returns a copy of the values and their aliases whose names
+// are their names in a format, as returned by name.
+func withFormatNames(values []value, name func(*value) string) []value {
+	names := make([]value, len(values))
+	for i, v := range values {
+		names[i] = v
+		names[i].name = name(&v)
+		names[i].aliases = withFormatNames(v.aliases, name)
+	}
+	return names
+}
+
+// buildNames generates the lists of the names of the values that are parsed,
+// from which parse errors suggest corrections: _T_names, _T_text_names and
+// _T_sql_names if the names in those formats differ, and _T_graphql_names for
+// the GraphQL option. Deprecated values are not listed unless they all are.
+func (g *generator) buildNames(values []value, typeName string) {
+	var listed []value
+	for _, v := range values {
+		if !v.deprecated {
+			listed = append(listed, v)
+		}
+	}
+	if len(listed) == 0 {
+		listed = values
+	}
+	list := func(format string, name func(*value) string) {
+		names := make([]string, len(listed))
+		for i := range listed {
+			names[i] = strconv.Quote(name(&listed[i]))
+		}
+		g.printf("\nvar %s = []string{%s}\n", namesVar(values, typeName, format), strings.Join(names, ", "))
+	}
+	list("", func(v *value) string { return v.name })
+	if hasFormatNames(values, (*value).textName) {
+		list("text", (*value).textName)
+	}
+	if g.sqlNames && hasFormatNames(values, (*value).sqlName) {
+		list("sql", (*value).sqlName)
+	}
+	if g.GraphQL {
+		list("graphql", g.graphqlName)
+	}
+}
+
+// namesVar returns the name of the variable that lists the names of the values
+// in a format, "text", "sql" or "graphql", which is _T_names if they are the
+// names returned by String, see buildNames.
+func namesVar(values []value, typeName, format string) string {
+	switch format {
+	case "text":
+		if hasFormatNames(values, (*value).textName) {
+			return "_" + typeName + "_text_names"
+		}
+	case "sql":
+		if hasFormatNames(values, (*value).sqlName) {
+			return "_" + typeName + "_sql_names"
+		}
+	case "graphql":
+		return "_" + typeName + "_graphql_names"
+	}
+	return "_" + typeName + "_names"
+}
+
+// Helpers
+
+// usize returns the number of bits of the smallest unsigned integer
+// type that will hold n. Used to create the smallest possible slice of
+// integers to use as indexes into the concatenated strings.
+func usize(n int) int {
+	switch {
+	case n < 1<<8:
+		return 8
+	case n < 1<<16:
+		return 16
+	default:
+		// 2^32 is enough constants for anyone.
+		return 32
+	}
+}
+
+// declareIndexAndNameVars declares the index slices and concatenated names
+// strings representing the runs of values.
+func (g *generator) declareIndexAndNameVars(runs [][]value, typeName string) {
+	var indexes, names []string
+	for i, run := range runs {
+		index, name := g.createIndexAndNameDecl(run, typeName, fmt.Sprintf("_%d", i))
+		if len(run) != 1 {
+			indexes = append(indexes, index)
+		}
+		names = append(names, name)
+	}
+	g.printf("const (\n")
+	for _, name := range names {
+		g.printf("\t%s\n", name)
+	}
+	g.printf(")\n\n")
+
+	if len(indexes) > 0 {
+		g.printf("var (")
+		for _, index := range indexes {
+			g.printf("\t%s\n", index)
+		}
+		g.printf(")\n\n")
+	}
+}
+
+// declareIndexAndNameVar is the single-run version of declareIndexAndNameVars
+func (g *generator) declareIndexAndNameVar(run []value, typeName string) {
+	index, name := g.createIndexAndNameDecl(run, typeName, "")
+	g.printf("const %s\n", name)
+	g.printf("var %s\n", index)
+}
+
+// createIndexAndNameDecl returns the pair of declarations for the run. The caller will add "const" and "var".
+func (g *generator) createIndexAndNameDecl(run []value, typeName string, suffix string) (string, string) {
+	b := new(bytes.Buffer)
+	indexes := make([]int, len(run))
+	for i := range run {
+		b.WriteString(run[i].name)
+		indexes[i] = b.Len()
+	}
+	nameConst := fmt.Sprintf("_%s_name%s = %q", typeName, suffix, b.String())
+	nameLen := b.Len()
+	b.Reset()
+	fmt.Fprintf(b, "_%s_index%s = [...]uint%d{0, ", typeName, suffix, usize(nameLen))
+	for i, v := range indexes {
+		if i > 0 {
+			fmt.Fprintf(b, ", ")
+		}
+		fmt.Fprintf(b, "%d", v)
+	}
+	fmt.Fprintf(b, "}")
+	return b.String(), nameConst
+}
+
+// declareNameVars declares the concatenated names string representing all the values in the runs.
+func (g *generator) declareNameVars(runs [][]value, typeName string, suffix string) {
+	g.printf("const _%s_name%s = ", typeName, suffix)
+	b := new(bytes.Buffer)
+	for _, run := range runs {
+		for i := range run {
+			fmt.Fprintf(b, "%s", run[i].name)
+		}
+	}
+	g.printf("%q\n", b)
+}
+
+// buildOneRun generates the variables and String method for a single run of contiguous values.
+func (g *generator) buildOneRun(runs [][]value, typeName string) {
+	values := runs[0]
+	g.printf("\n")
+	g.declareIndexAndNameVar(values, typeName)
+	// The generated code is simple enough to write as a Printf format.
+	lessThanZero := ""
+	if values[0].signed {
+		lessThanZero = "i < 0 || "
+	}
+	if values[0].value == 0 { // Signed or unsigned, 0 is still 0.
+		g.printf(stringOneRun, typeName, usize(len(values)), lessThanZero)
+		if generateMarshalers {
+			g.printf(stringOneRunValid, typeName, usize(len(values)), lessThanZero)
+			if g.nameMarshalText() {
+				g.printf(stringOneRunMarshal, typeName, usize(len(values)), lessThanZero)
+			}
+		}
+		if g.SQL && !g.sqlNames {
+			g.printf(stringOneRunSQL, typeName, usize(len(values)), lessThanZero)
+		}
+	} else {
+		g.printf(stringOneRunWithOffset, typeName, values[0].String(), usize(len(values)), lessThanZero)
+		if generateMarshalers {
+			g.printf(stringOneRunWithOffsetValid, typeName, values[0].String(), usize(len(values)), lessThanZero)
+			if g.nameMarshalText() {
+				g.printf(stringOneRunWithOffsetMarshal, typeName, values[0].String(), usize(len(values)), lessThanZero)
+			}
+		}
+		if g.SQL && !g.sqlNames {
+			g.printf(stringOneRunWithOffsetSQL, typeName, values[0].String(), usize(len(values)), lessThanZero)
+		}
+	}
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: size of index element (8 for uint8 etc.)
+//	[3]: less than zero check (for signed types)
+const stringOneRun = `func (i %[1]s) String() string {
+	if %[3]si >= %[1]s(len(_%[1]s_index)-1) {
+		return "%[1]s(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _%[1]s_name[_%[1]s_index[i]:_%[1]s_index[i+1]]
+}
+`
+
+const stringOneRunValid = `
+func (i %[1]s) Valid() bool {
+	return !(%[3]si >= %[1]s(len(_%[1]s_index)-1))
+}
+`
+
+const stringOneRunMarshal = `
+func (i %[1]s) MarshalText() ([]byte, error) {
+	if %[3]si >= %[1]s(len(_%[1]s_index)-1) {
+		return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_%[1]s_name[_%[1]s_index[i]:_%[1]s_index[i+1]]), nil
+}
+`
+
+const stringOneRunSQL = `
+func (i %[1]s) Value() (driver.Value, error) {
+	if %[3]si >= %[1]s(len(_%[1]s_index)-1) {
+		return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return _%[1]s_name[_%[1]s_index[i]:_%[1]s_index[i+1]], nil
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: lowest defined value for type, as a string
+//	[3]: size of index element (8 for uint8 etc.)
+//	[4]: less than zero check (for signed types)
+/*
+ */
+const stringOneRunWithOffset = `func (i %[1]s) String() string {
+	i -= %[2]s
+	if %[4]si >= %[1]s(len(_%[1]s_index)-1) {
+		return "%[1]s(" + strconv.FormatInt(int64(i + %[2]s), 10) + ")"
+	}
+	return _%[1]s_name[_%[1]s_index[i] : _%[1]s_index[i+1]]
+}
+`
+
+const stringOneRunWithOffsetValid = `
+func (i %[1]s) Valid() bool {
+	i -= %[2]s
+	return !(%[4]si >= %[1]s(len(_%[1]s_index)-1))
+}
+`
+
+const stringOneRunWithOffsetMarshal = `
+func (i %[1]s) MarshalText() ([]byte, error) {
+	i -= %[2]s
+	if %[4]si >= %[1]s(len(_%[1]s_index)-1) {
+		return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.FormatInt(int64(i + %[2]s), 10)}
+	}
+	return []byte(_%[1]s_name[_%[1]s_index[i]:_%[1]s_index[i+1]]), nil
+}
+`
+
+const stringOneRunWithOffsetSQL = `
+func (i %[1]s) Value() (driver.Value, error) {
+	i -= %[2]s
+	if %[4]si >= %[1]s(len(_%[1]s_index)-1) {
+		return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.FormatInt(int64(i + %[2]s), 10)}
+	}
+	return _%[1]s_name[_%[1]s_index[i]:_%[1]s_index[i+1]], nil
+}
+`
+
+// buildMultipleRuns generates the variables and String method for multiple runs of contiguous values.
+// For this pattern, a single Printf format won't do.
+func (g *generator) buildMultipleRuns(runs [][]value, typeName string) {
+	g.printf("\n")
+	g.declareIndexAndNameVars(runs, typeName)
+	g.printf("func (i %s) String() string {\n", typeName)
+	g.printf("\tswitch {\n")
+	for i, values := range runs {
+		if len(values) == 1 {
+			g.printf("\tcase i == %s:\n", &values[0])
+			g.printf("\t\treturn _%s_name_%d\n", typeName, i)
+			continue
+		}
+		if values[0].value == 0 && !values[0].signed {
+			// For an unsigned lower bound of 0, "0 <= i" would be redundant.
+			g.printf("\tcase i <= %s:\n", &values[len(values)-1])
+		} else {
+			g.printf("\tcase %s <= i && i <= %s:\n", &values[0], &values[len(values)-1])
+		}
+		if values[0].value != 0 {
+			g.printf("\t\ti -= %s\n", &values[0])
+		}
+		g.printf("\t\treturn _%s_name_%d[_%s_index_%d[i]:_%s_index_%d[i+1]]\n",
+			typeName, i, typeName, i, typeName, i)
+	}
+	g.printf("\tdefault:\n")
+	g.printf("\t\treturn \"%s(\" + strconv.FormatInt(int64(i), 10) + \")\"\n", typeName)
+	g.printf("\t}\n")
+	g.printf("}\n")
+
+	if generateMarshalers {
+		g.multipleRunsValid(runs, typeName)
+	}
+}
+
+func (g *generator) multipleRunsValid(runs [][]value, typeName string) {
+	g.printf("\n")
+	g.printf("func (i %s) Valid() bool {\n", typeName)
+	g.printf("\tswitch {\n")
+	for _, values := range runs {
+		if len(values) == 1 {
+			g.printf("\tcase i == %s:\n", &values[0])
+			continue
+		}
+		if values[0].value == 0 && !values[0].signed {
+			// For an unsigned lower bound of 0, "0 <= i" would be redundant.
+			g.printf("\tcase i <= %s:\n", &values[len(values)-1])
+		} else {
+			g.printf("\tcase %s <= i && i <= %s:\n", &values[0], &values[len(values)-1])
+		}
+	}
+	g.printf("\tdefault:\n")
+	g.printf("\t\treturn false\n")
+	g.printf("\t}\n")
+	g.printf("\treturn true\n")
+	g.printf("}\n")
+
+	if g.nameMarshalText() {
+		g.printf(stringMultipleRunsMarshal, typeName)
+	}
+	if g.SQL && !g.sqlNames {
+		g.printf(stringMultipleRunsSQL, typeName)
+	}
+}
+
+const stringMultipleRunsMarshal = `
+func (i %[1]s) MarshalText() ([]byte, error) {
+	if i.Valid() {
+		return []byte(i.String()), nil
+	}
+	return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.FormatInt(int64(i), 10)}
+}
+`
+
+const stringMultipleRunsSQL = `
+func (i %[1]s) Value() (driver.Value, error) {
+	if i.Valid() {
+		return i.String(), nil
+	}
+	return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.FormatInt(int64(i), 10)}
+}
+`
+
+// buildMap handles the case where the space is so sparse a map is a reasonable fallback.
+// It's a rare situation but has simple code.
+func (g *generator) buildMap(runs [][]value, typeName string) {
+	g.printf("\n")
+	g.declareNameVars(runs, typeName, "")
+	g.printf("\nvar _%s_map = map[%s]string{\n", typeName, typeName)
+	n := 0
+	for _, values := range runs {
+		for _, value := range values {
+			g.printf("\t%s: _%s_name[%d:%d],\n", &value, typeName, n, n+len(value.name))
+			n += len(value.name)
+		}
+	}
+	g.printf("}\n\n")
+	g.printf(stringMap, typeName)
+	if generateMarshalers {
+		g.printf(stringMapValid, typeName)
+		if g.nameMarshalText() {
+			g.printf(stringMapMarhalers, typeName)
+		}
+	}
+	if g.SQL && !g.sqlNames {
+		g.printf(stringMapSQL, typeName)
+	}
+}
+
+// Argument to format is the type name.
+const stringMap = `func (i %[1]s) String() string {
+	if str, ok := _%[1]s_map[i]; ok {
+		return str
+	}
+	return "%[1]s(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+`
+
+const stringMapValid = `
+func (i %[1]s) Valid() bool {
+	_, ok := _%[1]s_map[i]
+	return ok
+}
+`
+
+const stringMapMarhalers = `
+func (i %[1]s) MarshalText() ([]byte, error) {
+	if str, ok := _%[1]s_map[i]; ok {
+		return []byte(str), nil
+	}
+	return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.FormatInt(int64(i), 10)}
+}
+`
+
+const stringMapSQL = `
+func (i %[1]s) Value() (driver.Value, error) {
+	if str, ok := _%[1]s_map[i]; ok {
+		return str, nil
+	}
+	return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.FormatInt(int64(i), 10)}
+}
+`
+
+// Strategies of the String method for sparse values, see Options.Sparse.
+const (
+	sparseMap    = "map"
+	sparseSearch = "search"
+)
+
+// useSearch reports whether the String method of the runs is generated by
+// buildSearch, which declares the _T_values table of buildValues.
+func (g *generator) useSearch(runs [][]value) bool {
+	return len(runs) > 10 && g.Sparse == sparseSearch
+}
+
+// buildSearch handles the same case as buildMap with static tables of the
+// sorted values and of the offsets of their names, which are searched with
+// a binary search. Unlike the map, the tables cost nothing at startup.
+func (g *generator) buildSearch(runs [][]value, typeName string) {
+	values := make([]value, 0, countValues(runs))
+	names := make([]string, 0, countValues(runs))
+	for _, run := range runs {
+		values = append(values, run...)
+		for _, v := range run {
+			names = append(names, v.originalName)
+		}
+	}
+	g.printf("\n")
+	g.declareIndexAndNameVar(values, typeName)
+	g.printf("var _%s_values = [...]%s{%s}\n\n", typeName, typeName, strings.Join(names, ", "))
+	g.printf(stringSearch, typeName)
+	if generateMarshalers {
+		g.printf(stringSearchValid, typeName)
+		if g.nameMarshalText() {
+			g.printf(stringSearchMarshalers, typeName)
+		}
+	}
+	if g.SQL && !g.sqlNames {
+		g.printf(stringSearchSQL, typeName)
+	}
+}
+
+// Argument to format is the type name.
+const stringSearch = `func (i %[1]s) String() string {
+	if n := _%[1]s_search(i); n >= 0 {
+		return _%[1]s_name[_%[1]s_index[n]:_%[1]s_index[n+1]]
+	}
+	return "%[1]s(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+
+// _%[1]s_search returns the position of i in _%[1]s_values or -1.
+func _%[1]s_search(i %[1]s) int {
+	lo, hi := 0, len(_%[1]s_values)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if _%[1]s_values[m] < i {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	if lo < len(_%[1]s_values) && _%[1]s_values[lo] == i {
+		return lo
+	}
+	return -1
+}
+`
+
+const stringSearchValid = `
+func (i %[1]s) Valid() bool {
+	return _%[1]s_search(i) >= 0
+}
+`
+
+const stringSearchMarshalers = `
+func (i %[1]s) MarshalText() ([]byte, error) {
+	if n := _%[1]s_search(i); n >= 0 {
+		return []byte(_%[1]s_name[_%[1]s_index[n]:_%[1]s_index[n+1]]), nil
+	}
+	return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.FormatInt(int64(i), 10)}
+}
+`
+
+const stringSearchSQL = `
+func (i %[1]s) Value() (driver.Value, error) {
+	if n := _%[1]s_search(i); n >= 0 {
+		return _%[1]s_name[_%[1]s_index[n]:_%[1]s_index[n+1]], nil
+	}
+	return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.FormatInt(int64(i), 10)}
+}
+`
+
+const genericScanSQL = `
+func (i *%[1]s) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case string:
+		return i.Set(s)
+	case []byte:
+		return i.UnmarshalText(s)
+	default:
+		return fmt.Errorf("cannot scan type %%T into %[1]s", src)
+	}
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: default constant
+const genericScanSQLDefault = `
+func (i *%[1]s) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case string:
+		if i.Set(s) != nil {
+			// Unknown names are scanned as the default value.
+			*i = %[2]s
+		}
+		return nil
+	case []byte:
+		return i.UnmarshalText(s)
+	default:
+		return fmt.Errorf("cannot scan type %%T into %[1]s", src)
+	}
+}
+`
+
+// printGenericScanSQL prints the Scan method that parses the names with the
+// Set and UnmarshalText methods.
+func (g *generator) printGenericScanSQL(typeName string) {
+	if g.fallback != "" {
+		g.printf(genericScanSQLDefault, typeName, g.fallback)
+	} else {
+		g.printf(genericScanSQL, typeName)
+	}
+}
+
+// buildTextNames generates the MarshalText and UnmarshalText methods of a type
+// whose values have names in the text format that differ from those returned
+// by String, or that are marshaled as their replacements. The methods of the
+// runs are omitted in that case.
+func (g *generator) buildTextNames(values []value, typeName string) {
+	g.printf("\nfunc (i %s) MarshalText() ([]byte, error) {\n", typeName)
+	g.printf("\tswitch i {\n")
+	for _, v := range values {
+		g.printf("\tcase %s:\n", v.originalName)
+		g.printf("\t\treturn []byte(%q), nil\n", v.marshalName())
+	}
+	g.printf("\t}\n")
+	if g.Lenient {
+		g.printf("\treturn []byte(i.String()), nil\n")
+	} else {
+		g.printf("\treturn nil, &InvalidValueError{Type: \"%s\", Value: strconv.FormatInt(int64(i), 10)}\n", typeName)
+	}
+	g.printf("}\n")
+	g.buildFormatParse(withFormatNames(values, (*value).textName), typeName, "text")
+	g.printUnmarshalText(typeName, "", fmt.Sprintf("_%s_text(string(s))", typeName), namesVar(values, typeName, "text"))
+}
+
+// buildSQLNames generates the Value and Scan methods of a type whose values
+// have names in the SQL format that differ from those returned by String.
+// The methods of the runs are omitted in that case.
+func (g *generator) buildSQLNames(values []value, typeName string) {
+	g.printf("\nfunc (i %s) Value() (driver.Value, error) {\n", typeName)
+	g.printf("\tswitch i {\n")
+	for _, v := range values {
+		g.printf("\tcase %s:\n", v.originalName)
+		g.printf("\t\treturn %q, nil\n", v.sqlName())
+	}
+	g.printf("\t}\n")
+	g.printf("\treturn nil, &InvalidValueError{Type: \"%s\", Value: strconv.FormatInt(int64(i), 10)}\n", typeName)
+	g.printf("}\n")
+	g.buildFormatParse(withFormatNames(values, (*value).sqlName), typeName, "sql")
+	if g.fallback != "" {
+		g.printf(stringScanSQLDefault, typeName, g.fallback)
+	} else {
+		g.printf(stringScanSQL, typeName, namesVar(values, typeName, "sql"))
+	}
+}
+
+// buildFormatParse generates the function _T_format, which returns the
+// constant named by a string in the format, ignoring case if the -nocase
+// flag is set. The names of the values are their names in the format.
+func (g *generator) buildFormatParse(values []value, typeName, format string) {
+	g.printf("\nfunc _%s_%s(s string) (%s, bool) {\n", typeName, format, typeName)
+	g.printf("\tswitch s {\n")
+	for _, v := range values {
+		for _, k := range v.keys() {
+			g.printf("\tcase %q:\n", k.name)
+			g.printf("\t\treturn %s, true\n", v.originalName)
+		}
+	}
+	g.printf("\t}\n")
+	if g.NoCase {
+		g.printf("\tswitch {\n")
+		for _, v := range values {
+			for _, k := range v.keys() {
+				g.printf("\tcase strings.EqualFold(s, %q):\n", k.name)
+				g.printf("\t\treturn %s, true\n", v.originalName)
+			}
+		}
+		g.printf("\t}\n")
+	}
+	switch {
+	case g.Lenient && format != "graphql":
+		// GraphQL enums are strict: only the names in the schema are valid.
+		g.printf("\treturn _%s_number(s)\n", typeName)
+	case values[0].isString():
+		g.printf("\treturn \"\", false\n")
+	default:
+		g.printf("\treturn 0, false\n")
+	}
+	g.printf("}\n")
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: list of the names that are parsed, see buildNames
+const stringScanSQL = `
+func (i *%[1]s) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan type %%T into %[1]s", src)
+	}
+	if v, ok := _%[1]s_sql(s); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "%[1]s", Input: s, names: %[2]s}
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: default constant
+const stringScanSQLDefault = `
+func (i *%[1]s) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan type %%T into %[1]s", src)
+	}
+	if v, ok := _%[1]s_sql(s); ok {
+		*i = v
+		return nil
+	}
+	// Unknown names are scanned as the default value.
+	*i = %[2]s
+	return nil
+}
+`
+
+// buildValues generates the functions listing the values of the type.
+// Values whose constants are all deprecated are not listed, unless no
+// value is left.
+func (g *generator) buildValues(runs [][]value, typeName string) {
+	var names, all []string
+	for _, values := range runs {
+		for _, v := range values {
+			if !v.deprecated {
+				names = append(names, v.originalName)
+			}
+			all = append(all, v.originalName)
+		}
+	}
+	// If all the values are deprecated they are all listed.
+	deprecated := len(names) != 0 && len(names) != len(all)
+	if !deprecated {
+		names = all
+	}
+	zero := "0"
+	if runs[0][0].isString() {
+		zero = `""`
+	}
+	list := fmt.Sprintf("_%s_values", typeName)
+	if g.useSearch(runs) {
+		if !deprecated {
+			// The values and their binary search are shared with String.
+			g.printf(valuesFuncs, typeName, len(names), zero, list)
+			g.printf(valuesIndexSearchShared, typeName)
+			return
+		}
+		list = fmt.Sprintf("_%s_listed_values", typeName)
+	}
+	g.printf("\nvar %s = [...]%s{%s}\n", list, typeName, strings.Join(names, ", "))
+	g.printf(valuesFuncs, typeName, len(names), zero, list)
+	if runs[0][0].isString() || len(runs) != 1 || deprecated {
+		g.printf(valuesIndexSearch, typeName, list)
+		return
+	}
+	lessThanZero := ""
+	if runs[0][0].signed {
+		lessThanZero = "i < 0 || "
+	}
+	offset := ""
+	if runs[0][0].value != 0 {
+		offset = fmt.Sprintf("\ti -= %s\n", &runs[0][0])
+	}
+	g.printf(valuesIndexOneRun, typeName, offset, lessThanZero)
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: number of values
+//	[3]: zero value of the type
+//	[4]: name of the array of the listed values
+const valuesFuncs = `
+const %[1]sCount = %[2]d
+
+func %[1]sValues() []%[1]s {
+	values := %[4]s
+	return values[:]
+}
+
+func %[1]sNames() []string {
+	names := make([]string, len(%[4]s))
+	for i, v := range %[4]s {
+		names[i] = v.String()
+	}
+	return names
+}
+
+func %[1]sFromIndex(n int) (%[1]s, bool) {
+	if n < 0 || n >= len(%[4]s) {
+		return %[3]s, false
+	}
+	return %[4]s[n], true
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: statement subtracting the lowest value
+//	[3]: less than zero check (for signed types)
+const valuesIndexOneRun = `
+func (i %[1]s) Index() int {
+%[2]s	if %[3]si >= %[1]s(len(_%[1]s_values)) {
+		return -1
+	}
+	return int(i)
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: name of the array of the listed values
+const valuesIndexSearch = `
+func (i %[1]s) Index() int {
+	lo, hi := 0, len(%[2]s)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if %[2]s[m] < i {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	if lo < len(%[2]s) && %[2]s[lo] == i {
+		return lo
+	}
+	return -1
+}
+`
+
+// Argument to format is the type name.
+const valuesIndexSearchShared = `
+func (i %[1]s) Index() int {
+	return _%[1]s_search(i)
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: name of the array of the listed values
+const valuesIndexBitmask = `
+func (i %[1]s) Index() int {
+	for j, v := range %[2]s {
+		if i == v {
+			return j
+		}
+	}
+	return -1
+}
+`
+
+func countValues(runs [][]value) int {
+	n := 0
+	for _, values := range runs {
+		n += len(values)
+	}
+	return n
+}
+
+func (g *generator) buildUnmarshalers(runs [][]value, typeName string, multipleRuns bool) error {
+	if countValues(runs) == 0 {
+		panic("no values defined for type " + typeName)
+	}
+	if g.Lenient || g.numberEncoding() {
+		g.buildParseNumber(typeName, runs[0][0].signed)
+	}
+	return g.buildLookup(lookupKeys(runs, typeName, multipleRuns), typeName, "0")
+}
+
+// Formats of the LogValue method, see Options.Slog.
+const (
+	slogAuto  = "auto"
+	slogName  = "name"
+	slogGroup = "group"
+	slogNone  = "none"
+)
+
+// logValue returns the format of the LogValue method to generate, "name" or
+// "group", or "" if none is generated.
+func (g *generator) logValue() string {
+	switch g.Slog {
+	case slogName, slogGroup:
+		return g.Slog
+	case "", slogAuto:
+		if goVersionAtLeast(g.pkg.goVersion, 21) {
+			return slogName
+		}
+	}
+	return ""
+}
+
+// goVersionAtLeast reports whether a Go version of a go.mod file, such as
+// "1.21" or "1.21.0", is at least 1.minor. It is false for an empty version.
+func goVersionAtLeast(version string, minor int) bool {
+	if !strings.HasPrefix(version, "1.") {
+		return false
+	}
+	n := 0
+	for _, c := range version[len("1."):] {
+		if c < '0' || c > '9' {
+			break
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n >= minor
+}
+
+// buildLogValue generates the LogValue method, if any, see logValue.
+func (g *generator) buildLogValue(values []value, typeName string) {
+	switch g.logValue() {
+	case slogName:
+		g.printf(stringLogValue, typeName)
+	case slogGroup:
+		if values[0].signed {
+			g.printf(stringLogValueGroup, typeName, "Int64", "int64")
+		} else {
+			g.printf(stringLogValueGroup, typeName, "Uint64", "uint64")
+		}
+	}
+}
+
+// Argument to format is the type name.
+const stringLogValue = `
+func (i %[1]s) LogValue() slog.Value {
+	return slog.StringValue(i.String())
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: slog function of the integer value, Int64 or Uint64
+//	[3]: integer type of the value, int64 or uint64
+const stringLogValueGroup = `
+func (i %[1]s) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("name", i.String()), slog.%[2]s("value", %[3]s(i)))
+}
+`
+
+// buildFormat generates the Format method, which prints the values as
+// numbers for the integer verbs, and the GoString method, which returns the
+// name of the constant qualified by the package name.
+func (g *generator) buildFormat(values []value, typeName string) {
+	intType, formatInt := "int64", "FormatInt"
+	if !values[0].signed {
+		intType, formatInt = "uint64", "FormatUint"
+	}
+	g.printf(stringFormat, typeName, intType, formatInt)
+
+	var cases bytes.Buffer
+	var bits []string
+	for _, v := range values {
+		fmt.Fprintf(&cases, "\tcase %s:\n\t\treturn %q\n", v.originalName, g.pkg.name+"."+v.originalName)
+		if v.value != 0 {
+			bits = append(bits, v.originalName)
+		}
+	}
+	if !g.Bitmask {
+		g.printf(stringGoString, typeName, cases.String(), g.pkg.name, intType, formatInt)
+		return
+	}
+	mask := "0"
+	if len(bits) != 0 {
+		mask = strings.Join(bits, " | ")
+	}
+	g.printf(stringGoStringBitmask, typeName, cases.String(), g.pkg.name, intType, formatInt, mask)
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: integer type of the value, int64 or uint64
+//	[3]: strconv function formatting the value, FormatInt or FormatUint
+const stringFormat = `
+func (i %[1]s) Format(f fmt.State, verb rune) {
+	var v interface{}
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			v = i.GoString()
+		case f.Flag('+') && i.Valid():
+			v = "%[1]s(" + i.String() + "=" + strconv.%[3]s(%[2]s(i), 10) + ")"
+		default:
+			v = i.String()
+		}
+		verb = 's'
+	case 's', 'q':
+		v = i.String()
+	default:
+		v = %[2]s(i)
+	}
+	format := "%%"
+	for _, c := range "+-# 0" {
+		if f.Flag(int(c)) {
+			format += string(c)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		format += strconv.Itoa(w)
+	}
+	if p, ok := f.Precision(); ok {
+		format += "." + strconv.Itoa(p)
+	}
+	fmt.Fprintf(f, format+string(verb), v)
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: switch cases returning the qualified name of each constant
+//	[3]: package name
+//	[4]: integer type of the value, int64 or uint64
+//	[5]: strconv function formatting the value, FormatInt or FormatUint
+const stringGoString = `
+func (i %[1]s) GoString() string {
+	switch i {
+%[2]s	}
+	return "%[3]s.%[1]s(" + strconv.%[5]s(%[4]s(i), 10) + ")"
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: switch cases returning the qualified name of each flag
+//	[3]: package name
+//	[4]: integer type of the value, int64 or uint64
+//	[5]: strconv function formatting the value, FormatInt or FormatUint
+//	[6]: union of the flags
+const stringGoStringBitmask = `
+func (i %[1]s) GoString() string {
+	switch i {
+%[2]s	}
+	var s string
+	for _, v := range i.Flags() {
+		s += v.GoString() + "|"
+	}
+	if rest := i &^ (%[6]s); rest != 0 || s == "" {
+		s += "%[3]s.%[1]s(" + strconv.%[5]s(%[4]s(rest), 10) + ")|"
+	}
+	return s[:len(s)-1]
+}
+`
+
+// Transformations of the names of the values in the GraphQL schema, see
+// Options.GraphQLTransform.
+const (
+	graphqlUpper = "upper"
+	graphqlSnake = "snake"
+	graphqlLower = "lower"
+	graphqlNone  = "none"
+)
+
+// graphqlName returns the name of the value in the GraphQL schema, the name of
+// its constant with the prefix trimmed and transformed, see GraphQLTransform.
+func (g *generator) graphqlName(v *value) string {
+	name := strings.TrimPrefix(v.originalName, g.TrimPrefix)
+	switch g.GraphQLTransform {
+	case graphqlNone:
+		return name
+	case "":
+		return transforms[graphqlUpper](name)
+	}
+	return transforms[g.GraphQLTransform](name)
+}
+
+// buildGraphQL generates the MarshalGQL and UnmarshalGQL methods used by
+// gqlgen, which marshal the values as their names in the GraphQL schema, and
+// the enum declaring the type in the schema.
+func (g *generator) buildGraphQL(values []value, typeName string) {
+	g.printf("\nfunc (i %s) MarshalGQL(w io.Writer) {\n", typeName)
+	g.printf("\tvar s string\n")
+	g.printf("\tswitch i {\n")
+	for _, v := range values {
+		g.printf("\tcase %s:\n", v.originalName)
+		g.printf("\t\ts = %q\n", g.graphqlName(&v))
+	}
+	g.printf("\tdefault:\n")
+	g.printf("\t\ts = i.String()\n")
+	g.printf("\t}\n")
+	g.printf("\tio.WriteString(w, strconv.Quote(s))\n")
+	g.printf("}\n")
+	g.buildFormatParse(withFormatNames(values, g.graphqlName), typeName, "graphql")
+	if g.fallback != "" {
+		g.printf(stringUnmarshalGQLDefault, typeName, g.fallback)
+	} else {
+		g.printf(stringUnmarshalGQL, typeName, namesVar(values, typeName, "graphql"))
+	}
+
+	fmt.Fprintf(&g.sbuf, "\nenum %s {\n", typeName)
+	for _, v := range values {
+		fmt.Fprintf(&g.sbuf, "  %s", g.graphqlName(&v))
+		if v.deprecated {
+			g.sbuf.WriteString(" @deprecated")
+			if v.deprecation != "" {
+				reason := strings.Join(strings.Fields(v.deprecation), " ")
+				fmt.Fprintf(&g.sbuf, "(reason: %s)", strconv.Quote(reason))
+			}
+		}
+		g.sbuf.WriteString("\n")
+	}
+	g.sbuf.WriteString("}\n")
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: list of the names that are parsed, see buildNames
+const stringUnmarshalGQL = `
+func (i *%[1]s) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal type %%T into %[1]s", v)
+	}
+	if v, ok := _%[1]s_graphql(s); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "%[1]s", Input: s, names: %[2]s}
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: default constant
+const stringUnmarshalGQLDefault = `
+func (i *%[1]s) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal type %%T into %[1]s", v)
+	}
+	if v, ok := _%[1]s_graphql(s); ok {
+		*i = v
+		return nil
+	}
+	// Unknown names are unmarshaled as the default value.
+	*i = %[2]s
+	return nil
+}
+`
+
+// Encodings of the text marshalers, see Options.Encoding.
+const (
+	encodingName       = "name"
+	encodingNumber     = "number"
+	encodingNumberName = "number,name"
+)
+
+// numberEncoding reports whether the text marshalers encode the values as
+// numbers.
+func (g *generator) numberEncoding() bool {
+	return g.Encoding == encodingNumber || g.Encoding == encodingNumberName
+}
+
+// nameMarshalText reports whether the MarshalText method returns the names
+// of the runs, which is generated with the String method.
+func (g *generator) nameMarshalText() bool {
+	return !g.textNames && !g.Lenient && !g.numberEncoding()
+}
+
+// nameUnmarshalText reports whether the UnmarshalText method looks up the
+// names of the runs, which is generated with the Set method.
+func (g *generator) nameUnmarshalText() bool {
+	return !g.textNames && !g.numberEncoding()
+}
+
+// buildBinary generates the binary marshalers, which encode the values as
+// varints. Only valid values are marshaled and unmarshaled unless the -lenient
+// flag is set.
+func (g *generator) buildBinary(values []value, typeName string) {
+	varint, intType, format := "Varint", "int64", "FormatInt"
+	if !values[0].signed {
+		varint, intType, format = "Uvarint", "uint64", "FormatUint"
+	}
+	check, valid := "", ""
+	if !g.Lenient {
+		check = fmt.Sprintf(stringBinaryCheck, typeName, intType, format)
+		valid = " || !v.Valid()"
+	}
+	g.printf(stringBinary, typeName, varint, intType, format, check, valid)
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: integer type of the varint, int64 or uint64
+//	[3]: strconv function formatting the varint
+const stringBinaryCheck = `	if !i.Valid() {
+		return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.%[3]s(%[2]s(i), 10)}
+	}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: name of the varint encoding, Varint or Uvarint
+//	[3]: integer type of the varint, int64 or uint64
+//	[4]: strconv function formatting the varint
+//	[5]: validity check of AppendBinary, see stringBinaryCheck
+//	[6]: validity check of UnmarshalBinary
+const stringBinary = `
+func (i %[1]s) AppendBinary(b []byte) ([]byte, error) {
+%[5]s	return binary.Append%[2]s(b, %[3]s(i)), nil
+}
+
+func (i %[1]s) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(nil)
+}
+
+func (i *%[1]s) UnmarshalBinary(data []byte) error {
+	x, n := binary.%[2]s(data)
+	if n <= 0 || n != len(data) {
+		return &ParseError{Type: "%[1]s", Input: hex.EncodeToString(data)}
+	}
+	v := %[1]s(x)
+	if %[3]s(v) != x%[6]s {
+		return &InvalidValueError{Type: "%[1]s", Value: strconv.%[4]s(x, 10)}
+	}
+	*i = v
+	return nil
+}
+`
+
+// buildYAML generates the YAML marshalers, which marshal the values as the
+// text marshalers do: as strings, or as integers with the number encoding.
+// Their signatures are those of gopkg.in/yaml.v2, which yaml.v3 also supports,
+// so the generated code does not import a YAML package.
+func (g *generator) buildYAML(values []value, typeName string) {
+	if !g.numberEncoding() {
+		g.printf(stringYAML, typeName, "text", "string(text)")
+	} else if values[0].signed {
+		g.printf(stringYAML, typeName, "_", "int64(i)")
+	} else {
+		g.printf(stringYAML, typeName, "_", "uint64(i)")
+	}
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: variable assigned the text returned by MarshalText, or "_"
+//	[3]: value returned by MarshalYAML
+const stringYAML = `
+func (i %[1]s) MarshalYAML() (interface{}, error) {
+	%[2]s, err := i.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return %[3]s, nil
+}
+
+func (i *%[1]s) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return i.UnmarshalText([]byte(s))
+}
+`
+
+// Argument to format is the type name.
+const stringXML = `
+func (i %[1]s) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	text, err := i.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+func (i *%[1]s) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.UnmarshalText([]byte(attr.Value))
+}
+
+func (i %[1]s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	text, err := i.MarshalText()
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(string(text), start)
+}
+
+func (i *%[1]s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return i.UnmarshalText([]byte(s))
+}
+`
+
+// buildNumberEncoding generates the text and JSON marshalers of the number
+// encoding, which parse the numbers with the _T_number function.
+func (g *generator) buildNumberEncoding(values []value, typeName string) {
+	g.printf("\nfunc (i %s) MarshalText() ([]byte, error) {\n", typeName)
+	if !g.Lenient {
+		g.printf("\tif !i.Valid() {\n")
+		g.printf("\t\treturn nil, &InvalidValueError{Type: \"%s\", Value: strconv.FormatInt(int64(i), 10)}\n", typeName)
+		g.printf("\t}\n")
+	}
+	if values[0].signed {
+		g.printf("\treturn strconv.AppendInt(nil, int64(i), 10), nil\n")
+	} else {
+		g.printf("\treturn strconv.AppendUint(nil, uint64(i), 10), nil\n")
+	}
+	g.printf("}\n")
+
+	g.printf("\nfunc (i *%s) UnmarshalText(s []byte) error {\n", typeName)
+	if g.Lenient {
+		g.printf("\tif v, ok := _%s_number(string(s)); ok {\n", typeName)
+	} else {
+		g.printf("\tif v, ok := _%s_number(string(s)); ok && v.Valid() {\n", typeName)
+	}
+	g.printf("\t\t*i = v\n")
+	g.printf("\t\treturn nil\n")
+	g.printf("\t}\n")
+	if g.Encoding == encodingNumberName {
+		g.printf("\tvar v %s\n", typeName)
+		g.printf("\tif v.Set(string(s)) == nil {\n")
+		g.printf("\t\t*i = v\n")
+		g.printf("\t\treturn nil\n")
+		g.printf("\t}\n")
+	}
+	if g.fallback != "" {
+		g.printf("\t// Unknown values are unmarshaled as the default value.\n")
+		g.printf("\t*i = %s\n", g.fallback)
+		g.printf("\treturn nil\n")
+	} else {
+		if g.Encoding == encodingNumberName {
+			g.printf("\treturn &ParseError{Type: \"%[1]s\", Input: string(s), names: _%[1]s_names}\n", typeName)
+		} else {
+			g.printf("\treturn &ParseError{Type: \"%s\", Input: string(s)}\n", typeName)
+		}
+	}
+	g.printf("}\n")
+	g.printf(stringNumberJSON, typeName)
+}
+
+// Argument to format is the type name.
+const stringNumberJSON = `
+func (i %[1]s) MarshalJSON() ([]byte, error) {
+	return i.MarshalText()
+}
+
+func (i *%[1]s) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) != 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = []byte(s)
+	}
+	return i.UnmarshalText(data)
+}
+`
+
+// buildJSON generates the MarshalJSON and UnmarshalJSON methods, which write
+// and parse the quoted names without the reflection of encoding/json and the
+// copies made by it to quote the result of MarshalText.
+func (g *generator) buildJSON(values []value, typeName string) {
+	switch {
+	case g.textNames:
+		// The names differ from those returned by String, so they are
+		// quoted here.
+		g.printf("\nfunc (i %s) MarshalJSON() ([]byte, error) {\n", typeName)
+		g.printf("\tswitch i {\n")
+		for _, v := range values {
+			g.printf("\tcase %s:\n", v.originalName)
+			g.printf("\t\treturn []byte(%q), nil\n", jsonQuote(v.marshalName()))
+		}
+		g.printf("\t}\n")
+		if g.Lenient {
+			g.printf("\treturn []byte(\"\\\"\" + i.String() + \"\\\"\"), nil\n")
+		} else {
+			g.printf("\t_, err := i.MarshalText()\n")
+			g.printf("\treturn nil, err\n")
+		}
+		g.printf("}\n")
+	case needsJSONEscape(values):
+		g.printf(stringJSONMarshalQuote, typeName)
+	default:
+		check := stringJSONMarshalCheck
+		if g.Lenient {
+			check = ""
+		}
+		g.printf(stringJSONMarshal, typeName, check)
+	}
+	g.printf(stringJSONUnmarshal, typeName)
+}
+
+// jsonQuote returns s as a JSON string.
+func jsonQuote(s string) string {
+	b, err := json.Marshal(s)
+	if err != nil {
+		panic(err) // Strings are always valid.
+	}
+	return string(b)
+}
+
+// needsJSONEscape reports whether any of the names of the values must be
+// escaped in a JSON string.
+func needsJSONEscape(values []value) bool {
+	for _, v := range values {
+		if !utf8.ValidString(v.name) {
+			return true
+		}
+		for _, r := range v.name {
+			if r < ' ' || r == '"' || r == '\\' || r == '\u2028' || r == '\u2029' {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: check of the validity of the value, see stringJSONMarshalCheck
+const stringJSONMarshal = `
+func (i %[1]s) MarshalJSON() ([]byte, error) {
+%[2]s	s := i.String()
+	b := make([]byte, len(s)+2)
+	b[0] = '"'
+	copy(b[1:], s)
+	b[len(b)-1] = '"'
+	return b, nil
+}
+`
+
+const stringJSONMarshalCheck = `	if !i.Valid() {
+		_, err := i.MarshalText()
+		return nil, err
+	}
+`
+
+// Argument to format is the type name.
+const stringJSONMarshalQuote = `
+func (i %[1]s) MarshalJSON() ([]byte, error) {
+	text, err := i.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+`
+
+// Argument to format is the type name.
+const stringJSONUnmarshal = `
+func (i *%[1]s) UnmarshalJSON(data []byte) error {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		if string(data) == "null" {
+			return nil
+		}
+		return &ParseError{Type: "%[1]s", Input: string(data)}
+	}
+	s := data[1 : len(data)-1]
+	for _, c := range s {
+		if c == '\\' {
+			// Decode the escape sequences.
+			var u string
+			if err := json.Unmarshal(data, &u); err != nil {
+				return err
+			}
+			return i.UnmarshalText([]byte(u))
+		}
+	}
+	return i.UnmarshalText(s)
+}
+`
+
+// Lookup strategies of the unmarshal methods, see Options.Lookup.
+const (
+	lookupAuto   = "auto"
+	lookupSwitch = "switch"
+	lookupMap    = "map"
+	lookupPhash  = "phash"
+)
+
+// buildLookup generates the unmarshal methods, which match the keys using
+// the strategy selected by the Lookup option. Zero is the zero value of the
+// type.
+func (g *generator) buildLookup(keys []lookupKey, typeName, zero string) error {
+	lookup := g.Lookup
+	if lookup == "" || lookup == lookupAuto {
+		// Use a map when there are more than 32 values. A switch is slightly
+		// faster with 64 values but adds a lot of code for a marginal gain.
+		//
+		// See: internal/bench_lookup/bench_lookup_test.go for benchmark results.
+		lookup = lookupSwitch
+		if len(keys) > 32 {
+			lookup = lookupMap
+		}
+	}
+	switch lookup {
+	case lookupSwitch:
+		g.buildUnmarshalersSwitch(keys, typeName, zero)
+	case lookupMap:
+		g.buildUnmarshalersMap(keys, typeName, zero)
+	case lookupPhash:
+		return g.buildUnmarshalersPhash(keys, typeName, zero)
+	default:
+		// Generate validates the option.
+		panic("invalid lookup strategy: " + lookup)
+	}
+	return nil
+}
+
+// lookupKey is a string that is parsed as one of the constants of a type.
+type lookupKey struct {
+	expr string // Go expression that evaluates to the key.
+	key  string // The key.
+	name string // Name of the constant the key is parsed as.
+}
+
+// lookupKeys returns the keys accepted when parsing values of the type. The
+// expression of each key is a slice of the name constants declared for the
+// runs.
+func lookupKeys(runs [][]value, typeName string, multipleRuns bool) []lookupKey {
+	keys := make([]lookupKey, 0, countValues(runs))
+	if multipleRuns {
+		for i, values := range runs {
+			if len(values) == 1 {
+				keys = append(keys, lookupKey{
+					expr: fmt.Sprintf("_%s_name_%d", typeName, i),
+					key:  values[0].name,
+					name: values[0].originalName,
+				})
+				continue
+			}
+			n := 0
+			for _, value := range values {
+				keys = append(keys, lookupKey{
+					expr: fmt.Sprintf("_%s_name_%d[%d:%d]", typeName, i, n, n+len(value.name)),
+					key:  value.name,
+					name: value.originalName,
+				})
+				n += len(value.name)
+			}
+		}
+	} else {
+		n := 0
+		for _, values := range runs {
+			// TODO: avoid index on single values (use Prime test)
+			for _, value := range values {
+				keys = append(keys, lookupKey{
+					expr: fmt.Sprintf("_%s_name[%d:%d]", typeName, n, n+len(value.name)),
+					key:  value.name,
+					name: value.originalName,
+				})
+				n += len(value.name)
+			}
+		}
+	}
+	// The names of aliases are not part of the name constants.
+	for _, values := range runs {
+		for _, value := range values {
+			for _, a := range value.keys()[1:] {
+				keys = append(keys, lookupKey{
+					expr: strconv.Quote(a.name),
+					key:  a.name,
+					name: value.originalName,
+				})
+			}
+		}
+	}
+	return keys
+}
+
+// buildUnmarshalersSwitch generates the unmarshal methods, which match the
+// keys with a switch statement.
+func (g *generator) buildUnmarshalersSwitch(keys []lookupKey, typeName, zero string) {
+	marshalers := []struct {
+		funcName, switchVal string
+	}{
+		{"Set(s string)", "s"},
+		{"UnmarshalText(s []byte)", "string(s)"},
+	}
+	if !g.nameUnmarshalText() {
+		// UnmarshalText parses the names of the text format or numbers.
+		marshalers = marshalers[:1]
+	}
+	for _, m := range marshalers {
+		g.printf("\nfunc (i *%s) %s (err error) {\n", typeName, m.funcName)
+		g.printf("\tswitch %s {\n", m.switchVal)
+		for _, k := range keys {
+			g.printf("\tcase %s:\n", k.expr)
+			g.printf("\t\t*i = %s\n", k.name)
+		}
+		g.printf("\tdefault :\n")
+		if g.NoCase {
+			g.printf("\t\tif v, ok := _%s_fold(%s); ok {\n", typeName, m.switchVal)
+			g.printf("\t\t\t*i = v\n")
+			g.printf("\t\t\treturn nil\n")
+			g.printf("\t\t}\n")
+		}
+		if g.Lenient {
+			g.printf("\t\tif v, ok := _%s_number(%s); ok {\n", typeName, m.switchVal)
+			g.printf("\t\t\t*i = v\n")
+			g.printf("\t\t\treturn nil\n")
+			g.printf("\t\t}\n")
+		}
+		if g.fallback != "" && m.switchVal != "s" {
+			// Unknown names are unmarshaled as the default value.
+			g.printf("\t\t*i = %s\n", g.fallback)
+		} else {
+			g.printf("\t\terr = &ParseError{Type: \"%[1]s\", Input: %[2]s, names: _%[1]s_names}\n", typeName, m.switchVal)
+		}
+		g.printf("\t}\n")
+		g.printf("\treturn err\n")
+		g.printf("}\n\n")
+	}
+	if g.NoCase {
+		g.buildFold(keys, typeName, zero, false)
+	}
+	g.printf("\n")
+	if g.SQL && !g.sqlNames {
+		g.printGenericScanSQL(typeName)
+		g.printf("\n")
+	}
+}
+
+// buildUnmarshalersMap generates the unmarshal methods, which look up the
+// keys in a map.
+func (g *generator) buildUnmarshalersMap(keys []lookupKey, typeName, zero string) {
+	g.printf("\nvar _%s_lookup_map = map[string]%s{\n", typeName, typeName)
+	for _, k := range keys {
+		g.printf("\t%s: %s,\n", k.expr, k.name)
+	}
+	g.printf("}\n\n")
+	g.printLookupUnmarshalers(keys, typeName, zero, "_"+typeName+"_lookup_map[%s]", true)
+}
+
+// buildUnmarshalersPhash generates the unmarshal methods, which look up the
+// keys in a minimal perfect hash table computed by newPhash. It fails if no
+// perfect hash function is found for the keys.
+func (g *generator) buildUnmarshalersPhash(keys []lookupKey, typeName, zero string) error {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.key
+	}
+	ph, err := newPhash(names)
+	if err != nil {
+		return fmt.Errorf("lookup strategy %q cannot be used with type %s: %s", lookupPhash, typeName, err)
+	}
+
+	// The keys are stored in the order of their slots.
+	var ordered []lookupKey
+	slots := make([]string, len(ph.slots))
+	for i, k := range ph.slots {
+		slots[i] = "0"
+		if k != 0 {
+			ordered = append(ordered, keys[k-1])
+			slots[i] = strconv.Itoa(len(ordered))
+		}
+	}
+	var b strings.Builder
+	index := make([]string, 0, len(ordered)+1)
+	values := make([]string, 0, len(ordered))
+	index = append(index, "0")
+	for _, k := range ordered {
+		b.WriteString(k.key)
+		index = append(index, strconv.Itoa(b.Len()))
+		values = append(values, k.name)
+	}
+	disp := make([]string, len(ph.disp))
+	for i, d := range ph.disp {
+		disp[i] = strconv.FormatUint(uint64(d), 10)
+	}
+
+	g.printf("\nconst _%s_phash_names = %q\n\n", typeName, b.String())
+	g.printf("var (\n")
+	g.printf("\t_%s_phash_index = [...]uint%d{%s}\n", typeName, usize(b.Len()), strings.Join(index, ", "))
+	g.printf("\t_%s_phash_values = [...]%s{%s}\n", typeName, typeName, strings.Join(values, ", "))
+	g.printf("\t_%s_phash_disp = [...]uint32{%s}\n", typeName, strings.Join(disp, ", "))
+	g.printf("\t_%s_phash_slots = [...]uint%d{%s}\n", typeName, usize(len(ordered)), strings.Join(slots, ", "))
+	g.printf(")\n")
+	g.printf(stringPhashLookup, typeName, ph.seed, len(ph.disp)-1, 32-ph.bits, zero)
+	g.printLookupUnmarshalers(keys, typeName, zero, "_"+typeName+"_phash(%s)", false)
+	return nil
+}
+
+// Argument to format is the type name.
+const stringLenientMarshal = `
+func (i %[1]s) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+`
+
+// buildParseNumber generates the function _T_number, which parses the bare
+// integers of the number encoding and of the -lenient flag. With -lenient it
+// also parses the form returned by String for invalid values, "T(n)".
+func (g *generator) buildParseNumber(typeName string, signed bool) {
+	desc, prefix := "bare integers", ""
+	if g.Lenient {
+		desc = fmt.Sprintf("the form returned by String for invalid values,\n// \"%s(n)\", and bare integers", typeName)
+		prefix = fmt.Sprintf(stringParseNumberPrefix, typeName)
+		if !signed {
+			prefix = fmt.Sprintf(stringParseNumberPrefixUnsigned, typeName)
+		}
+	}
+	parse, intType := "ParseInt", "int64"
+	if !signed {
+		parse, intType = "ParseUint", "uint64"
+	}
+	g.printf(stringParseNumber, typeName, desc, prefix, parse, intType)
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: description of the parsed strings
+//	[3]: code removing the prefix of the form "T(n)", or empty
+//	[4]: strconv function parsing the integer, ParseInt or ParseUint
+//	[5]: integer type of the parsed integer, int64 or uint64
+const stringParseNumber = `
+// _%[1]s_number parses %[2]s.
+func _%[1]s_number(s string) (%[1]s, bool) {
+%[3]s	n, err := strconv.%[4]s(s, 10, 64)
+	if err != nil || %[5]s(%[1]s(n)) != n {
+		return 0, false
+	}
+	return %[1]s(n), true
+}
+`
+
+// Argument to format is the type name.
+const stringParseNumberPrefix = `	const prefix = "%[1]s("
+	if len(s) > len(prefix) && s[:len(prefix)] == prefix && s[len(s)-1] == ')' {
+		s = s[len(prefix) : len(s)-1]
+	}
+`
+
+// Argument to format is the type name.
+const stringParseNumberPrefixUnsigned = `	const prefix = "%[1]s("
+	if len(s) > len(prefix) && s[:len(prefix)] == prefix && s[len(s)-1] == ')' {
+		s = s[len(prefix) : len(s)-1]
+		// String formats the values above math.MaxInt64 as negative.
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil && n < 0 {
+			return %[1]s(n), int64(%[1]s(n)) == n
+		}
+	}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: initial state of the hash
+//	[3]: mask selecting the bucket of a hash
+//	[4]: shift selecting the slot of a displaced hash
+//	[5]: zero value of the type
+const stringPhashLookup = `
+func _%[1]s_phash(s string) (%[1]s, bool) {
+	h := uint32(%[2]d)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	h ^= _%[1]s_phash_disp[h&%[3]d]
+	k := int(_%[1]s_phash_slots[(h*0x9e3779b1)>>%[4]d])
+	if k == 0 || s != _%[1]s_phash_names[_%[1]s_phash_index[k-1]:_%[1]s_phash_index[k]] {
+		return %[5]s, false
+	}
+	return _%[1]s_phash_values[k-1], true
+}
+`
+
+// phash is a perfect hash function for a set of keys, built with the "hash
+// and displace" method. A key is hashed with FNV-1a and the low bits of the
+// hash select a bucket, whose displacement is xored into the hash before
+// the high bits of its product with a constant select the slot of the key.
+// The generated function _T_phash computes the same hash.
+type phash struct {
+	seed  uint32   // Initial state of the hash.
+	bits  uint     // Number of bits of the slot index.
+	disp  []uint32 // Displacement of each bucket.
+	slots []int    // Index of the key in each slot plus one, or zero if empty.
+}
+
+// newPhash returns a perfect hash function for the keys, which must be
+// distinct. There are at least twice as many slots as keys, which keeps the
+// search for the displacements short.
+func newPhash(keys []string) (*phash, error) {
+	bits := uint(1)
+	for 1<<bits < 2*len(keys) {
+		bits++
+	}
+	buckets := 1
+	for buckets*4 < len(keys) {
+		buckets <<= 1
+	}
+	// The seeds are tried in order so that the output is reproducible.
+	for seed := uint32(2166136261); seed < 2166136261+1000; seed++ {
+		p := &phash{
+			seed:  seed,
+			bits:  bits,
+			disp:  make([]uint32, buckets),
+			slots: make([]int, 1<<bits),
+		}
+		if p.place(keys) {
+			return p, nil
+		}
+	}
+	return nil, errors.New("cannot build a perfect hash function for the keys")
+}
+
+// hash returns the FNV-1a hash of s.
+func (p *phash) hash(s string) uint32 {
+	h := p.seed
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h
+}
+
+// slot returns the slot of the hash h displaced by d.
+func (p *phash) slot(h, d uint32) int {
+	return int(((h ^ d) * 0x9e3779b1) >> (32 - p.bits))
+}
+
+// place searches for the displacements of the buckets that place every key
+// in its own slot. It reports false if the keys must be hashed with another
+// seed.
+func (p *phash) place(keys []string) bool {
+	hashes := make([]uint32, len(keys))
+	seen := make(map[uint32]bool, len(keys))
+	buckets := make([][]int, len(p.disp))
+	mask := uint32(len(p.disp) - 1)
+	for i, k := range keys {
+		h := p.hash(k)
+		if seen[h] {
+			return false
+		}
+		seen[h] = true
+		hashes[i] = h
+		buckets[h&mask] = append(buckets[h&mask], i)
+	}
+	// Place the largest buckets first, while most slots are free.
+	order := make([]int, len(buckets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(buckets[order[i]]) > len(buckets[order[j]])
+	})
+	slots := make([]int, 0, 8)
+	for _, b := range order {
+		if len(buckets[b]) == 0 {
+			break
+		}
+		found := false
+	Search:
+		for d := uint32(0); d < 1<<16; d++ {
+			slots = slots[:0]
+			for _, i := range buckets[b] {
+				s := p.slot(hashes[i], d)
+				if p.slots[s] != 0 {
+					continue Search
+				}
+				for _, t := range slots {
+					if t == s {
+						continue Search
+					}
+				}
+				slots = append(slots, s)
+			}
+			for j, i := range buckets[b] {
+				p.slots[slots[j]] = i + 1
+			}
+			p.disp[b] = d
+			found = true
+			break
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// printLookupUnmarshalers prints the unmarshal methods that look up strings
+// with the lookup expression: a format with a single verb for the string that
+// evaluates to the value and whether the string was found. The strings not
+// found are looked up ignoring case if the -nocase flag is set, in the fold
+// map if useMap is set, and then parsed as numbers if the -lenient flag is.
+func (g *generator) printLookupUnmarshalers(keys []lookupKey, typeName, zero, lookup string, useMap bool) {
+	var setFold, unmarshalFold string
+	if g.NoCase {
+		g.buildFold(keys, typeName, zero, useMap)
+		setFold = fmt.Sprintf(stringMapUnmarshalersFold, typeName, "s")
+		unmarshalFold = fmt.Sprintf(stringMapUnmarshalersFold, typeName, "string(s)")
+	}
+	if g.Lenient {
+		setFold += fmt.Sprintf(stringMapUnmarshalersNumber, typeName, "s")
+		unmarshalFold += fmt.Sprintf(stringMapUnmarshalersNumber, typeName, "string(s)")
+	}
+	g.printf(stringMapUnmarshalers, typeName, setFold, fmt.Sprintf(lookup, "s"))
+	if g.nameUnmarshalText() {
+		// Otherwise UnmarshalText parses the names of the text format or numbers.
+		g.printUnmarshalText(typeName, unmarshalFold, fmt.Sprintf(lookup, "string(s)"), "_"+typeName+"_names")
+	}
+	g.printf("\n")
+	if g.SQL && !g.sqlNames {
+		g.printGenericScanSQL(typeName)
+		g.printf("\n")
+	}
+}
+
+// TODO: consider renaming
+//
+// Arguments to format are:
+//	[1]: type name
+//	[2]: fallbacks of the Set method, see printLookupUnmarshalers
+//	[3]: lookup of the Set method
+const stringMapUnmarshalers = `
+func (i *%[1]s) Set(s string) error {
+	if v, ok := %[3]s; ok {
+		*i = v
+		return nil
+	}
+%[2]s	return &ParseError{Type: "%[1]s", Input: s, names: _%[1]s_names}
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: fallbacks of the UnmarshalText method, see printLookupUnmarshalers
+//	[3]: lookup of the UnmarshalText method
+//	[4]: list of the names that are parsed, see buildNames
+const stringMapUnmarshalText = `
+func (i *%[1]s) UnmarshalText(s []byte) error {
+	if v, ok := %[3]s; ok {
+		*i = v
+		return nil
+	}
+%[2]s	return &ParseError{Type: "%[1]s", Input: string(s), names: %[4]s}
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: fallbacks of the UnmarshalText method, see printLookupUnmarshalers
+//	[3]: lookup of the UnmarshalText method
+//	[4]: default constant
+const stringMapUnmarshalTextDefault = `
+func (i *%[1]s) UnmarshalText(s []byte) error {
+	if v, ok := %[3]s; ok {
+		*i = v
+		return nil
+	}
+%[2]s	// Unknown names are unmarshaled as the default value.
+	*i = %[4]s
+	return nil
+}
+`
+
+// printUnmarshalText prints the UnmarshalText method that looks up the names
+// with the lookup expression, followed by the fallbacks of the names that are
+// not found, see printLookupUnmarshalers. The names are listed by the variable
+// names, see buildNames.
+func (g *generator) printUnmarshalText(typeName, fold, lookup, names string) {
+	if g.fallback != "" {
+		g.printf(stringMapUnmarshalTextDefault, typeName, fold, lookup, g.fallback)
+	} else {
+		g.printf(stringMapUnmarshalText, typeName, fold, lookup, names)
+	}
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: string to look up
+const stringMapUnmarshalersFold = `	if v, ok := _%[1]s_fold(%[2]s); ok {
+		*i = v
+		return nil
+	}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: string to parse
+const stringMapUnmarshalersNumber = `	if v, ok := _%[1]s_number(%[2]s); ok {
+		*i = v
+		return nil
+	}
+`
+
+// buildFold generates the function _T_fold, which matches a string against
+// the keys ignoring case. It is the fallback of the unmarshal methods when
+// the -nocase flag is set. If useMap is true the keys are matched by looking
+// up the case-folded string in a map, otherwise they are compared one by one
+// with strings.EqualFold, which matches the same strings.
+func (g *generator) buildFold(keys []lookupKey, typeName, zero string, useMap bool) {
+	if useMap {
+		g.printf("\nvar _%s_fold_map = map[string]%s{\n", typeName, typeName)
+		seen := make(map[string]bool, len(keys))
+		for _, k := range keys {
+			// Aliases of a constant may only differ from it by case.
+			key := foldCase(k.key)
+			if !seen[key] {
+				seen[key] = true
+				g.printf("\t%q: %s,\n", key, k.name)
+			}
+		}
+		g.printf("}\n\n")
+		g.printf("func _%s_fold(s string) (%s, bool) {\n", typeName, typeName)
+		g.printf("\tv, ok := _%s_fold_map[strings.Map(_enum_foldRune, s)]\n", typeName)
+		g.printf("\treturn v, ok\n")
+		g.printf("}\n")
+		return
+	}
+	g.printf("\nfunc _%s_fold(s string) (%s, bool) {\n", typeName, typeName)
+	g.printf("\tswitch {\n")
+	for _, k := range keys {
+		g.printf("\tcase strings.EqualFold(s, %s):\n", k.expr)
+		g.printf("\t\treturn %s, true\n", k.name)
+	}
+	g.printf("\t}\n")
+	g.printf("\treturn %s, false\n", zero)
+	g.printf("}\n")
+}
+
+// foldCase maps each rune of s to the smallest rune that it equals ignoring
+// case, as the generated code does with the _enum_foldRune function of the
+// errors file.
+func foldCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		min := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < min {
+				min = f
+			}
+		}
+		return min
+	}, s)
+}
+
+// checkForFoldedDuplicateStrings checks for values whose string forms only
+// differ by case, which makes generating case-insensitive unmarshal methods
+// impossible.
+func checkForFoldedDuplicateStrings(typeName string, values []value) error {
+	dupes := false
+	seen := make(map[string][]string, len(values))
+	for _, v := range values {
+		// Aliases may differ from each other only by case.
+		folded := make(map[string]bool)
+		for _, k := range v.keys() {
+			name := foldCase(k.name)
+			if folded[name] {
+				continue
+			}
+			folded[name] = true
+			seen[name] = append(seen[name], k.originalName)
+			dupes = dupes || len(seen[name]) > 1
+		}
+	}
+	if !dupes {
+		return nil
+	}
+	var buf bytes.Buffer
+	for name, origNames := range seen {
+		if len(origNames) == 1 {
+			continue
+		}
+		if buf.Len() != 0 {
+			buf.WriteString("; ")
+		}
+		fmt.Fprintf(&buf, "%s == %s", origNames, name)
+	}
+	return fmt.Errorf("cannot generate case-insensitive unmarshal methods for type: %s found "+
+		"values with strings representations that only differ by case: %s",
+		typeName, &buf)
+}
+
+// bitmaskWidth returns a mask of the bits used by values of the type of v.
+func bitmaskWidth(typeName string, v value) uint64 {
+	_, max := typeMinMax(typeName, v.kind)
+	if v.signed {
+		return max<<1 | 1
+	}
+	return max
+}
+
+// unsignedTypes maps signed integer kinds to the unsigned type of the same size.
+var unsignedTypes = map[types.BasicKind]string{
+	types.Int:   "uint",
+	types.Int8:  "uint8",
+	types.Int16: "uint16",
+	types.Int32: "uint32",
+	types.Int64: "uint64",
+}
+
+// buildBitmask generates the variables and methods for a type whose constants
+// are bit flags. It returns the constants sorted by bit, preceded by the zero
+// value if one is defined.
+func (g *generator) buildBitmask(values []value, typeName string) ([]value, error) {
+	mask := bitmaskWidth(typeName, values[0])
+	flags := make([]value, 0, len(values))
+	for _, v := range values {
+		if u := v.value & mask; u&(u-1) != 0 {
+			return nil, fmt.Errorf("cannot generate bitmask methods for type: %s value %s (%s) "+
+				"is not a single bit", typeName, v.originalName, v.str)
+		}
+		flags = append(flags, v)
+	}
+	sort.SliceStable(flags, func(i, j int) bool {
+		return flags[i].value&mask < flags[j].value&mask
+	})
+	zero := "0"
+	bits := flags
+	if flags[0].value == 0 {
+		zero = flags[0].name
+		bits = flags[1:]
+	}
+	if len(bits) == 0 {
+		return nil, fmt.Errorf("no bit flags defined for type %s", typeName)
+	}
+
+	g.printf("\n")
+	g.declareIndexAndNameVar(bits, typeName)
+	names := make([]string, len(bits))
+	for i, v := range bits {
+		names[i] = v.originalName
+	}
+	g.printf("\nvar _%s_values = [...]%s{%s}\n", typeName, typeName, strings.Join(names, ", "))
+	g.printf("\nconst _%s_mask = %s\n\n", typeName, strings.Join(names, " | "))
+
+	conv := "uint64(i)"
+	if t, ok := unsignedTypes[bits[0].kind]; ok {
+		conv = fmt.Sprintf("uint64(%s(i))", t)
+	}
+	g.printf(stringBitmask, typeName, zero, conv)
+	if generateMarshalers {
+		g.printf(stringMultipleRunsMarshal, typeName)
+		if g.SQL {
+			g.printf(stringMultipleRunsSQL, typeName)
+		}
+		g.printf(stringBitmaskFlags, typeName)
+
+		keys := lookupKeys([][]value{bits}, typeName, false)
+		var cases bytes.Buffer
+		for _, k := range keys {
+			fmt.Fprintf(&cases, "\t\tcase %s:\n", k.expr)
+			fmt.Fprintf(&cases, "\t\t\tv |= %s\n", k.name)
+		}
+		zeroNames := []string{zero}
+		if flags[0].value == 0 {
+			zeroNames = zeroNames[:0]
+			for _, k := range flags[0].keys() {
+				zeroNames = append(zeroNames, k.name)
+			}
+		}
+		var isZero []string
+		for _, name := range zeroNames {
+			isZero = append(isZero, fmt.Sprintf("s == %q", name))
+		}
+		fallback := "\t\t\treturn 0, false\n"
+		if g.NoCase {
+			for i, name := range zeroNames {
+				isZero[i] = fmt.Sprintf("strings.EqualFold(s, %q)", name)
+			}
+			fallback = fmt.Sprintf(stringBitmaskFold, typeName)
+		}
+		g.printf(stringBitmaskUnmarshalers, typeName, strings.Join(isZero, " || "), cases.String(), fallback)
+		if g.NoCase {
+			g.buildFold(keys, typeName, "0", false)
+		}
+		if g.JSON {
+			g.buildJSON(flags, typeName)
+		}
+		if g.Binary {
+			g.buildBinary(flags, typeName)
+		}
+		if g.YAML {
+			g.buildYAML(flags, typeName)
+		}
+		if g.XML {
+			g.printf(stringXML, typeName)
+		}
+		if g.SQL {
+			g.printf(genericScanSQL, typeName)
+			g.printf("\n")
+		}
+	}
+	g.buildLogValue(flags, typeName)
+	if g.Formatter {
+		g.buildFormat(flags, typeName)
+	}
+	if g.Values {
+		// The deprecated flags are not listed, unless no flag is left.
+		list := fmt.Sprintf("_%s_values", typeName)
+		var listed []string
+		for _, v := range bits {
+			if !v.deprecated {
+				listed = append(listed, v.originalName)
+			}
+		}
+		if len(listed) == 0 || len(listed) == len(bits) {
+			listed = names
+		} else {
+			list = fmt.Sprintf("_%s_listed_values", typeName)
+			g.printf("\nvar %s = [...]%s{%s}\n", list, typeName, strings.Join(listed, ", "))
+		}
+		g.printf(valuesFuncs, typeName, len(listed), "0", list)
+		g.printf(valuesIndexBitmask, typeName, list)
+	}
+	return flags, nil
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: string representation of the zero value
+//	[3]: expression converting i to a uint64 without sign extension
+const stringBitmask = `func (i %[1]s) String() string {
+	if i == 0 {
+		return %[2]q
+	}
+	var b []byte
+	for j := 0; j < len(_%[1]s_values); j++ {
+		v := _%[1]s_values[j]
+		if i&v == 0 {
+			continue
+		}
+		if i == v && len(b) == 0 {
+			return _%[1]s_name[_%[1]s_index[j]:_%[1]s_index[j+1]]
+		}
+		if len(b) != 0 {
+			b = append(b, '|')
+		}
+		b = append(b, _%[1]s_name[_%[1]s_index[j]:_%[1]s_index[j+1]]...)
+		i &^= v
+	}
+	if i != 0 {
+		if len(b) != 0 {
+			b = append(b, '|')
+		}
+		b = append(b, "0x"...)
+		b = strconv.AppendUint(b, %[3]s, 16)
+	}
+	return string(b)
+}
+
+func (i %[1]s) Valid() bool {
+	return i&^_%[1]s_mask == 0
+}
+`
+
+const stringBitmaskFlags = `
+func (i %[1]s) Has(f %[1]s) bool {
+	return i&f == f
+}
+
+func (i %[1]s) SetFlag(f %[1]s) %[1]s {
+	return i | f
+}
+
+func (i %[1]s) ClearFlag(f %[1]s) %[1]s {
+	return i &^ f
+}
+
+func (i %[1]s) Flags() []%[1]s {
+	var flags []%[1]s
+	for _, v := range _%[1]s_values {
+		if i&v != 0 {
+			flags = append(flags, v)
+		}
+	}
+	return flags
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: condition that s is the string representation of the zero value
+//	[3]: switch cases matching the name of each flag
+//	[4]: body of the default case
+const stringBitmaskUnmarshalers = `
+func _%[1]s_parse(s string) (%[1]s, bool) {
+	if %[2]s {
+		return 0, true
+	}
+	var v %[1]s
+	for {
+		n := 0
+		for n < len(s) && s[n] != '|' {
+			n++
+		}
+		switch s[:n] {
+%[3]s		default:
+%[4]s		}
+		if n == len(s) {
+			return v, true
+		}
+		s = s[n+1:]
+	}
+}
+
+func (i *%[1]s) Set(s string) error {
+	if v, ok := _%[1]s_parse(s); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "%[1]s", Input: s, names: _%[1]s_names}
+}
+
+func (i *%[1]s) UnmarshalText(s []byte) error {
+	if v, ok := _%[1]s_parse(string(s)); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "%[1]s", Input: string(s), names: _%[1]s_names}
+}
+`
+
+// Argument to format is the type name.
+const stringBitmaskFold = `			f, ok := _%[1]s_fold(s[:n])
+			if !ok {
+				return 0, false
+			}
+			v |= f
+`
+
+// generateString produces the methods for a type whose underlying type is a
+// string. The value of each constant is also its name, so the -trimprefix,
+// -transform and -linecomment flags, which change the names, are rejected.
+func (g *generator) generateString(values, excluded []value, typeName string) error {
+	if g.TrimPrefix != "" || g.Transform != "" || g.LineComment {
+		return fmt.Errorf("cannot change the names of type %s: "+
+			"the underlying type is a string", typeName)
+	}
+	if g.Bitmask {
+		return fmt.Errorf("cannot generate bitmask methods for type: %s "+
+			"the underlying type is a string", typeName)
+	}
+	if g.Lenient {
+		return fmt.Errorf("cannot parse type %s leniently: "+
+			"the underlying type is a string", typeName)
+	}
+	if g.numberEncoding() {
+		return fmt.Errorf("cannot encode type %s as numbers: "+
+			"the underlying type is a string", typeName)
+	}
+	if g.Binary {
+		return fmt.Errorf("cannot encode type %s as binary: "+
+			"the underlying type is a string", typeName)
+	}
+	if g.logValue() == slogGroup {
+		return fmt.Errorf("cannot log type %s as a group: "+
+			"the underlying type is a string", typeName)
+	}
+	if g.Formatter {
+		return fmt.Errorf("cannot format type %s as a number: "+
+			"the underlying type is a string", typeName)
+	}
+
+	// Sort the values in increasing order, as for integer types, which is
+	// also the order of Values.
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].name < values[j].name
+	})
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = v.originalName
+	}
+	g.printf("\n")
+	g.printf(stringString, typeName, strings.Join(names, ", "))
+	if generateMarshalers {
+		g.printf(stringStringMarshal, typeName)
+		if g.SQL {
+			g.printf(stringStringSQL, typeName)
+		}
+		keys := make([]lookupKey, 0, len(values))
+		for _, v := range values {
+			keys = append(keys, lookupKey{expr: v.str, key: v.name, name: v.originalName})
+			// The names of aliases are not the values of constants.
+			for _, a := range v.keys()[1:] {
+				keys = append(keys, lookupKey{expr: strconv.Quote(a.name), key: a.name, name: v.originalName})
+			}
+		}
+		if err := g.buildLookup(keys, typeName, `""`); err != nil {
+			return err
+		}
+		if g.JSON {
+			g.buildJSON(values, typeName)
+		}
+		if g.YAML {
+			g.buildYAML(values, typeName)
+		}
+		if g.XML {
+			g.printf(stringXML, typeName)
+		}
+		if g.GraphQL {
+			g.buildGraphQL(values, typeName)
+		}
+	}
+	g.buildLogValue(values, typeName)
+	if g.Values {
+		g.buildValues([][]value{values}, typeName)
+	}
+	if generateTests {
+		g.buildStringTests(values, excluded, typeName)
+	}
+	return nil
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: comma-separated list of the constants
+const stringString = `func (i %[1]s) String() string {
+	return string(i)
+}
+
+func (i %[1]s) Valid() bool {
+	switch i {
+	case %[2]s:
+		return true
+	}
+	return false
+}
+`
+
+const stringStringMarshal = `
+func (i %[1]s) MarshalText() ([]byte, error) {
+	if i.Valid() {
+		return []byte(i), nil
+	}
+	return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.Quote(string(i))}
+}
+`
+
+const stringStringSQL = `
+func (i %[1]s) Value() (driver.Value, error) {
+	if i.Valid() {
+		return string(i), nil
+	}
+	return nil, &InvalidValueError{Type: "%[1]s", Value: strconv.Quote(string(i))}
+}
+`
+
+func typeMinMax(typeName string, kind types.BasicKind) (min, max uint64) {
+	// use u to defeat the compiler's overflow check
+	u := func(i int64) uint64 {
+		return uint64(i)
+	}
+	switch kind {
+	case types.Int:
+		if unsafe.Sizeof(int(0)) == 8 {
+			return u(math.MinInt64), math.MaxInt64
+		} else {
+			return u(math.MinInt32), math.MaxInt32
+		}
+	case types.Int8:
+		return u(math.MinInt8), math.MaxInt8
+	case types.Int16:
+		return u(math.MinInt16), math.MaxInt16
+	case types.Int32:
+		return u(math.MinInt32), math.MaxInt32
+	case types.Int64:
+		return u(math.MinInt64), math.MaxInt64
+	case types.Uint8:
+		return 0, math.MaxUint8
+	case types.Uint16:
+		return 0, math.MaxUint16
+	case types.Uint32:
+		return 0, math.MaxUint32
+	case types.Uint64:
+		return 0, math.MaxUint64
+	case types.Uint:
+		fallthrough
+	case types.Uintptr:
+		if unsafe.Sizeof(uint(0)) == 8 {
+			return 0, math.MaxUint64
+		} else {
+			return 0, math.MaxUint32
+		}
+	default:
+		// genDecl only accepts integer constants.
+		panic(fmt.Sprintf("invalid kind: %d for type: %s", kind, typeName))
+	}
+}
+
+func (g *generator) buildInvalidValues(runs [][]value, typeName string) map[uint64]value {
+	if len(runs) == 0 {
+		panic("no values defined for type " + typeName)
+	}
+
+	values := make(map[uint64]bool)
+	for _, run := range runs {
+		for _, v := range run {
+			values[v.value] = true
+		}
+	}
+
+	invalid := make(map[uint64]value)
+	first := runs[0][0]
+	signed := first.signed
+	min, max := typeMinMax(typeName, first.kind)
+	if !values[min] {
+		invalid[min] = value{signed: signed, value: min}
+	}
+	if !values[0] {
+		invalid[0] = value{signed: signed, value: 0}
+	}
+	negOne := -1 // work around the compilers overflow check
+	if signed && !values[uint64(negOne)] {
+		invalid[uint64(negOne)] = value{signed: signed, value: uint64(negOne)}
+	}
+	if !values[max] {
+		invalid[max] = value{signed: signed, value: max}
+	}
+
+	// TODO: a lot of the uint64 conversions can probably be removed
+
+	for _, run := range runs {
+		if len(runs) == 0 {
+			continue // can this happen?
+		}
+		first := run[0]
+		last := run[len(run)-1]
+		if signed {
+			if int64(first.value) > int64(min) {
+				u := uint64(int64(first.value) - 1)
+				if !values[u] {
+					invalid[u] = value{signed: signed, value: u}
+				}
+			}
+			// TODO: this is probably redundant
+			if int64(last.value) < int64(max) {
+				u := uint64(int64(last.value) + 1)
+				if !values[u] {
+					invalid[u] = value{signed: signed, value: u}
+				}
+			}
+		} else {
+			if first.value > 0 {
+				u := first.value - 1
+				if !values[u] {
+					invalid[u] = value{signed: signed, value: u}
+				}
+			}
+			if last.value < max {
+				u := last.value + 1
+				if !values[u] {
+					invalid[u] = value{signed: signed, value: u}
+				}
+			}
+		}
+	}
+
+	for u, v := range invalid {
+		if signed {
+			v.str = strconv.FormatInt(int64(v.value), 10)
+		} else {
+			v.str = strconv.FormatUint(v.value, 10)
+		}
+		invalid[u] = v
+	}
+	return invalid
+}
+
+func (g *generator) buildTests(runs [][]value, excluded []value, typeName string) {
+	invalid := g.buildInvalidValues(runs, typeName)
+	// Excluded constants are invalid unless another constant has their value.
+	valid := make(map[uint64]bool)
+	for _, run := range runs {
+		for _, v := range run {
+			valid[v.value] = true
+		}
+	}
+	for _, v := range excluded {
+		if !valid[v.value] {
+			invalid[v.value] = value{signed: v.signed, value: v.value, str: v.str}
+		}
+	}
+
+	values := make([]value, 0, 100+len(invalid))
+	for _, run := range runs {
+		values = append(values, run...)
+	}
+	for _, v := range invalid {
+		values = append(values, v)
+	}
+
+	sort.Stable(byValue(values))
+
+	var buf bytes.Buffer
+	for _, v := range values {
+		if _, ok := invalid[v.value]; ok {
+			fmt.Fprintf(&buf, "\t\t{%[1]s(%[2]s), \"%[1]s(%[3]d)\", false},\n",
+				typeName, v.str, int64(v.value))
+		} else {
+			fmt.Fprintf(&buf, "\t\t{%s, %q, true},\n", v.originalName, v.name)
+		}
+	}
+
+	tests := buf.String()
+
+	buf.Reset()
+	for _, run := range runs {
+		for _, v := range run {
+			fmt.Fprintf(&buf, "\t\t{%[1]s, %[2]q, []byte(%[3]q)},\n", v.originalName, v.name, v.textName())
+		}
+	}
+	var consts []value
+	for _, run := range runs {
+		consts = append(consts, run...)
+	}
+	g.writeTests(typeName, consts, tests, buf.String(), g.aliasTests(consts, typeName), false)
+}
+
+// formatNameFunc returns a function literal for the tests that returns the
+// name of a value in a format, as returned by name, which defaults to the name
+// returned by String.
+func formatNameFunc(values []value, typeName string, name func(*value) string) string {
+	if !hasFormatNames(values, name) {
+		return typeName + ".String"
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "func(v %s) string {\n", typeName)
+	fmt.Fprintf(&buf, "\t\tswitch v {\n")
+	for _, v := range values {
+		fmt.Fprintf(&buf, "\t\tcase %s:\n", v.originalName)
+		fmt.Fprintf(&buf, "\t\t\treturn %q\n", name(&v))
+	}
+	fmt.Fprintf(&buf, "\t\t}\n")
+	fmt.Fprintf(&buf, "\t\treturn v.String()\n")
+	fmt.Fprintf(&buf, "\t}")
+	return buf.String()
+}
+
+// goStringFunc returns a function literal for the tests that returns the
+// string returned by GoString, the qualified names of the constants. IntType
+// is the integer type the values are printed as, int64 or uint64.
+func (g *generator) goStringFunc(values []value, typeName, intType string) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "func(v %s) string {\n", typeName)
+	fmt.Fprintf(&buf, "\t\tswitch v {\n")
+	for _, v := range values {
+		fmt.Fprintf(&buf, "\t\tcase %s:\n", v.originalName)
+		fmt.Fprintf(&buf, "\t\t\treturn %q\n", g.pkg.name+"."+v.originalName)
+	}
+	fmt.Fprintf(&buf, "\t\t}\n")
+	if !g.Bitmask {
+		fmt.Fprintf(&buf, "\t\treturn \"%s.%s(\" + fmt.Sprint(%s(v)) + \")\"\n", g.pkg.name, typeName, intType)
+		fmt.Fprintf(&buf, "\t}")
+		return buf.String()
+	}
+	var bits []string
+	fmt.Fprintf(&buf, "\t\tvar s []string\n")
+	for _, v := range values {
+		if v.value != 0 {
+			bits = append(bits, v.originalName)
+			fmt.Fprintf(&buf, "\t\tif v&%s != 0 {\n", v.originalName)
+			fmt.Fprintf(&buf, "\t\t\ts = append(s, %q)\n", g.pkg.name+"."+v.originalName)
+			fmt.Fprintf(&buf, "\t\t}\n")
+		}
+	}
+	mask := "0"
+	if len(bits) != 0 {
+		mask = strings.Join(bits, " | ")
+	}
+	fmt.Fprintf(&buf, "\t\tif rest := v &^ (%s); rest != 0 || len(s) == 0 {\n", mask)
+	fmt.Fprintf(&buf, "\t\t\ts = append(s, \"%s.%s(\" + fmt.Sprint(%s(rest)) + \")\")\n", g.pkg.name, typeName, intType)
+	fmt.Fprintf(&buf, "\t\t}\n")
+	fmt.Fprintf(&buf, "\t\treturn strings.Join(s, \"|\")\n")
+	fmt.Fprintf(&buf, "\t}")
+	return buf.String()
+}
+
+// replacedFunc returns a function literal for the tests that returns the
+// value that a value is unmarshaled as after being marshaled, which is its
+// replacement if it is deprecated and replaced.
+func replacedFunc(values []value, typeName string) string {
+	var buf bytes.Buffer
+	for _, v := range values {
+		if v.replacement != nil {
+			fmt.Fprintf(&buf, "\t\tcase %s:\n", v.originalName)
+			fmt.Fprintf(&buf, "\t\t\treturn %s\n", v.replacement.originalName)
+		}
+	}
+	if buf.Len() == 0 {
+		return fmt.Sprintf("func(v %s) %s { return v }", typeName, typeName)
+	}
+	return fmt.Sprintf("func(v %s) %s {\n\t\tswitch v {\n%s\t\t}\n\t\treturn v\n\t}",
+		typeName, typeName, buf.String())
+}
+
+// fallbackValue returns the value that unknown names are unmarshaled as and
+// whether there is one, as an expression for the tests.
+func (g *generator) fallbackValue(typeName, zero string) string {
+	if g.fallback != "" {
+		return g.fallback + ", true"
+	}
+	return fmt.Sprintf("%s(%s), false", typeName, zero)
+}
+
+// aliasTests returns the subtest checking that the names of the aliases of
+// the values are parsed, or an empty string if there are no aliases.
+func (g *generator) aliasTests(values []value, typeName string) string {
+	var buf bytes.Buffer
+	for _, v := range values {
+		for _, a := range v.aliases {
+			text := a.textName()
+			if g.numberEncoding() {
+				text = a.str
+			}
+			fmt.Fprintf(&buf, "\t\t\t{%s, %q, %q},\n", a.originalName, a.name, text)
+		}
+	}
+	if buf.Len() == 0 {
+		return ""
+	}
+	return fmt.Sprintf(testTemplateAliases, typeName, buf.String())
+}
+
+// writeTests writes the test and benchmark functions for the type using the
+// test cases and benchmark cases built by the caller, which are for the given
+// values. Extra holds any additional subtests of the test function. StringType
+// reports whether the underlying type of the type is a string.
+func (g *generator) writeTests(typeName string, values []value, tests, benchmarks, extra string, stringType bool) {
+	var extraBenchmarks string
+	if g.SQL {
+		extra += fmt.Sprintf(testTemplateSQL, typeName, formatNameFunc(values, typeName, (*value).sqlName))
+		extraBenchmarks += fmt.Sprintf(benchmarkTemplateSQL)
+	}
+	if g.JSON || g.numberEncoding() {
+		extraBenchmarks += fmt.Sprintf(benchmarkTemplateJSON, typeName)
+	}
+	if g.NoCase {
+		extra += fmt.Sprintf(testTemplateNoCase, typeName)
+	}
+	if g.Values {
+		extra += fmt.Sprintf(testTemplateValues, typeName)
+	}
+	intType := "int64"
+	if !stringType && !values[0].signed {
+		intType = "uint64"
+	}
+	if g.Lenient {
+		extra += fmt.Sprintf(testTemplateLenient, typeName, intType)
+	}
+	if g.JSON && !g.numberEncoding() {
+		extra += fmt.Sprintf(testTemplateJSON, typeName)
+	}
+	if g.YAML {
+		extra += fmt.Sprintf(testTemplateYAML, typeName)
+	}
+	switch g.logValue() {
+	case slogName:
+		extra += testTemplateLogValue
+	case slogGroup:
+		intType := "int64"
+		if !values[0].signed {
+			intType = "uint64"
+		}
+		extra += fmt.Sprintf(testTemplateLogValueGroup, intType)
+	}
+	if g.XML {
+		extra += fmt.Sprintf(testTemplateXML, typeName)
+	}
+	if g.GraphQL {
+		extra += fmt.Sprintf(testTemplateGraphQL, typeName, formatNameFunc(values, typeName, g.graphqlName))
+	}
+	if g.Formatter {
+		intType := "int64"
+		if !values[0].signed {
+			intType = "uint64"
+		}
+		extra += fmt.Sprintf(testTemplateFormat, typeName, intType, g.goStringFunc(values, typeName, intType))
+	}
+	if g.Binary {
+		encode := "binary.AppendVarint(nil, int64(v))"
+		if !values[0].signed {
+			encode = "binary.AppendUvarint(nil, uint64(v))"
+		}
+		extra += fmt.Sprintf(testTemplateBinary, typeName, encode)
+	}
+	zero, invalidValue := "0", "fmt.Sprint(int64(v))"
+	if stringType {
+		zero, invalidValue = `""`, `fmt.Sprintf("%q", string(v))`
+	}
+	textName, jsonText := formatNameFunc(values, typeName, (*value).marshalName), "textName(x.Val)"
+	if g.numberEncoding() {
+		textName = fmt.Sprintf("func(v %s) string { return fmt.Sprint(%s(v)) }", typeName, intType)
+		jsonText = "json.Number(textName(x.Val))"
+	}
+	g.tprintf(testTemplate, typeName, tests, extra, invalidValue, zero, textName,
+		replacedFunc(values, typeName), g.fallbackValue(typeName, zero), g.Lenient, jsonText)
+	g.tprintf("\n")
+	g.tprintf(benchmarkTemplate, typeName, benchmarks, extraBenchmarks)
+	g.tprintf("\n")
+}
+
+// buildBitmaskTests generates tests for a bitmask type. The flags are those
+// returned by buildBitmask.
+func (g *generator) buildBitmaskTests(flags []value, typeName string) {
+	first := flags[0]
+	mask := bitmaskWidth(typeName, first)
+	zero := value{originalName: typeName + "(0)", name: "0"}
+	bits := flags
+	if first.value == 0 {
+		zero = first
+		bits = flags[1:]
+	}
+	var all uint64
+	for _, v := range bits {
+		all |= v.value & mask
+	}
+
+	// names returns the names of the flags set in u and the bits of u that
+	// do not belong to any flag.
+	names := func(u uint64) (orig, str []string, rest uint64) {
+		for _, v := range bits {
+			if u&v.value != 0 {
+				orig = append(orig, v.originalName)
+				str = append(str, v.name)
+				u &^= v.value & mask
+			}
+		}
+		return orig, str, u
+	}
+
+	var tests, benchmarks bytes.Buffer
+	valid := func(u uint64) {
+		orig, str := []string{zero.originalName}, []string{zero.name}
+		if u != 0 {
+			orig, str, _ = names(u)
+		}
+		expr, name := strings.Join(orig, " | "), strings.Join(str, "|")
+		fmt.Fprintf(&tests, "\t\t{%s, %q, true},\n", expr, name)
+		fmt.Fprintf(&benchmarks, "\t\t{%[1]s, %[2]q, []byte(%[2]q)},\n", expr, name)
+	}
+	invalid := func(u uint64) {
+		_, str, rest := names(u)
+		str = append(str, "0x"+strconv.FormatUint(rest, 16))
+		n := strconv.FormatUint(u, 10)
+		if first.signed && u&^(mask>>1) != 0 {
+			n = strconv.FormatInt(int64(u|^mask), 10)
+		}
+		fmt.Fprintf(&tests, "\t\t{%s(%s), %q, false},\n", typeName, n, strings.Join(str, "|"))
+	}
+
+	valid(0)
+	for i, v := range bits {
+		valid(v.value & mask)
+		if i > 0 {
+			valid((bits[i-1].value | v.value) & mask)
+		}
+	}
+	if len(bits) > 2 {
+		valid(all)
+	}
+	if unknown := mask &^ all; unknown != 0 {
+		low := unknown & -unknown
+		high := unknown
+		for high&(high-1) != 0 {
+			high &= high - 1
+		}
+		invalid(low)
+		invalid(all | low)
+		if high != low {
+			invalid(high)
+		}
+	}
+
+	g.writeTests(typeName, flags, tests.String(), benchmarks.String(),
+		fmt.Sprintf(testTemplateBitmask, typeName)+g.aliasTests(flags, typeName), false)
+}
+
+// buildStringTests generates tests for a type whose underlying type is a
+// string. The values are those sorted by generateString.
+func (g *generator) buildStringTests(values, excluded []value, typeName string) {
+	defined := make(map[string]bool, len(values))
+	for _, v := range values {
+		defined[v.name] = true
+	}
+	var tests, benchmarks bytes.Buffer
+	for _, v := range values {
+		fmt.Fprintf(&tests, "\t\t{%s, %s, true},\n", v.originalName, v.str)
+		fmt.Fprintf(&benchmarks, "\t\t{%[1]s, %[2]s, []byte(%[2]s)},\n", v.originalName, v.str)
+	}
+
+	// Invalid values: the empty string, strings that are close to but
+	// not one of the defined values, and the excluded values.
+	invalid := []string{"", values[0].name + "x", "x" + values[len(values)-1].name}
+	if !g.NoCase {
+		invalid = append(invalid, strings.ToUpper(values[0].name), strings.ToLower(values[0].name))
+	}
+Excluded:
+	for _, v := range excluded {
+		if g.NoCase {
+			for _, d := range values {
+				if strings.EqualFold(d.name, v.name) {
+					continue Excluded
+				}
+			}
+		}
+		invalid = append(invalid, v.name)
+	}
+	for _, s := range invalid {
+		if defined[s] {
+			continue
+		}
+		defined[s] = true
+		fmt.Fprintf(&tests, "\t\t{%[1]s(%[2]s), %[2]s, false},\n", typeName, strconv.Quote(s))
+	}
+	g.writeTests(typeName, values, tests.String(), benchmarks.String(), g.aliasTests(values, typeName), true)
+}
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: values to test
+//	[3]: additional subtests
+//	[4]: expression formatting the invalid value v in error messages
+//	[5]: zero value of the type
+//	[6]: function returning the name of a value in the text format
+const testTemplate = `
+var (
+	_ fmt.Stringer             = %[1]s(%[5]s)
+	_ encoding.TextMarshaler   = %[1]s(%[5]s)
+	_ encoding.TextUnmarshaler = (*%[1]s)(nil)
+	_ func() bool              = %[1]s(%[5]s).Valid    // Valid()
+	_ func(string) error       = (*%[1]s)(nil).Set // Set()
+)
+
+func TestGeneratedEnum_%[1]s(t *testing.T) {
+	const _TypeName = "%[1]s"
+	var tests = []struct {
+		Val   %[1]s
+		Str   string
+		Valid bool
+	}{
+%[2]s
+	}
+
+	invalidValueError := func(v %[1]s) string {
+		return "invalid " + _TypeName + ": " + %[4]s
+	}
+
+	// The empty string is only invalid if no value has an empty name.
+	var empty %[1]s
+	emptyValid := empty.Set("") == nil
+
+	// The names of the values in the text format.
+	textName := %[6]s
+
+	// The values that the values are unmarshaled as after being marshaled,
+	// which differ for the deprecated values that are replaced.
+	replaced := %[7]s
+
+	// The value that unknown names are unmarshaled as, if any.
+	fallback, hasFallback := %[8]s
+
+	// Whether invalid values are marshaled and parsed in the form "T(n)".
+	const lenient = %[9]t
+
+	testUnmarshalError := func(t *testing.T, err error, s string) {
+		t.Helper()
+		if err == nil {
+			t.Error("expected a non-nil error")
+			return
+		}
+		var exp string
+		if len(s) <= 32 {
+			exp = fmt.Sprintf("malformed %%s: %%q", _TypeName, s)
+		} else {
+			exp = fmt.Sprintf("malformed %%s: %%q...", _TypeName, s[0:29])
+		}
+		// The message may end with suggested or valid names.
+		if msg := err.Error(); msg != exp && !strings.HasPrefix(msg, exp+" (") {
+			t.Errorf("unmarshal error: got: %%s want: %%s", msg, exp)
+		}
+		var perr *ParseError
+		if !errors.As(err, &perr) || perr.Type != _TypeName {
+			t.Errorf("unmarshal error: got: %%#v want: *ParseError", err)
+		} else if names := perr.Names(); len(names) != 0 {
+			names[0] = ""
+			if perr.Names()[0] == "" {
+				t.Errorf("unmarshal error: modifying the result of Names changed the names")
+			}
+		}
+		if !errors.Is(err, ErrMalformed) {
+			t.Errorf("unmarshal error: %%v does not match ErrMalformed", err)
+		}
+	}
+
+	// testUnknown checks that unmarshaling the unknown name s as v either
+	// failed or set v to the fallback value.
+	testUnknown := func(t *testing.T, err error, v %[1]s, s string) {
+		t.Helper()
+		var exp %[1]s
+		if hasFallback {
+			if err != nil {
+				t.Errorf("%%q: unexpected error: %%v", s, err)
+			}
+			exp = fallback
+		} else {
+			testUnmarshalError(t, err, s)
+		}
+		if v != exp {
+			t.Errorf("%%q: got: %%s want: %%s", s, v, exp)
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		for _, x := range tests {
+			if x.Val.Valid() != x.Valid {
+				t.Errorf("%%+v: got: %%t want: %%t", x, x.Val.Valid(), x.Valid)
+			}
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		for _, x := range tests {
+			str := x.Val.String()
+			if str != x.Str {
+				t.Errorf("%%+v: got: %%q want: %%q", x, str, x.Str)
+			}
+		}
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		var zeroValue %[1]s
+		for _, x := range tests {
+			var v %[1]s
+			err := v.Set(x.Str)
+			if (err == nil) != (x.Valid || lenient) {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			exp := x.Val
+			if !x.Valid && !lenient {
+				testUnmarshalError(t, err, x.Str)
+				exp = zeroValue
+			}
+			if v != exp {
+				t.Errorf("%%+v: got: %%s want: %%s", x, v, exp)
+			}
+		}
+
+		// Test that we don't include long strings in the error message
+		var v %[1]s
+		invalid := strings.Repeat("a", 256) + "\x00" // this should not collide
+		testUnmarshalError(t, v.Set(invalid), invalid)
+	})
+
+	t.Run("MarshalJSON", func(t *testing.T) {
+		for _, x := range tests {
+			data, err := json.Marshal(x.Val)
+			if (err == nil) != (x.Valid || lenient) {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if !x.Valid && !lenient {
+				if data != nil {
+					t.Errorf("%%+v: expected []byte(nil) on error got: %%v", x, data)
+				}
+				merr, ok := err.(*json.MarshalerError)
+				if !ok {
+					t.Errorf("%%+v: invalid error type: %%T", x, err)
+				}
+				exp := invalidValueError(x.Val)
+				if merr.Err.Error() != exp {
+					t.Errorf("%%+v: got: %%s want: %%s", x, merr.Err.Error(), exp)
+				}
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("%%+v: %%v does not match ErrInvalid", x, err)
+				}
+				continue
+			}
+
+			exp, err := json.Marshal(%[10]s)
+			if err != nil {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if string(data) != string(exp) {
+				t.Errorf("%%+v: got: '%%s' want: '%%s'", x, data, exp)
+			}
+			var v %[1]s
+			if err := json.Unmarshal(data, &v); err != nil {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if v != replaced(x.Val) {
+				t.Errorf("%%+v: got: %%s want: %%s", x, v, replaced(x.Val))
+			}
+		}
+	})
+
+	t.Run("UnmarshalJSON", func(t *testing.T) {
+		for _, x := range tests {
+			data, err := json.Marshal(x.Val)
+			if (err == nil) != (x.Valid || lenient) {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if !x.Valid {
+				// Set the data to the string value, which is also invalid.
+				data, err = json.Marshal(x.Str)
+				if err != nil {
+					t.Fatalf("%%+v: %%v", x, err)
+				}
+			}
+
+			var v %[1]s
+			err = json.Unmarshal(data, &v)
+			if !x.Valid && !lenient {
+				testUnknown(t, err, v, x.Str)
+				continue
+			}
+			if err != nil {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if v != replaced(x.Val) {
+				t.Errorf("%%+v: got: %%s want: %%s", x, v, replaced(x.Val))
+			}
+		}
+
+		// Test that we don't include long strings in the error message
+		var v %[1]s
+		invalid := strings.Repeat("a", 256) + "\\u0000" // this should not collide
+		err := json.Unmarshal([]byte("\""+invalid+"\""), &v)
+		testUnknown(t, err, v, invalid)
+	})
+
+	t.Run("MarshalText", func(t *testing.T) {
+		for _, x := range tests {
+			data, err := x.Val.MarshalText()
+			if (err == nil) != (x.Valid || lenient) {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if !x.Valid && !lenient {
+				if data != nil {
+					t.Errorf("%%+v: expected []byte(nil) on error got: %%v", x, data)
+				}
+				exp := invalidValueError(x.Val)
+				if err.Error() != exp {
+					t.Errorf("%%+v: got: %%s want: %%s", x, err.Error(), exp)
+				}
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("%%+v: %%v does not match ErrInvalid", x, err)
+				}
+				continue
+			}
+
+			if string(data) != textName(x.Val) {
+				t.Errorf("%%+v: got: '%%s' want: '%%s'", x, data, textName(x.Val))
+			}
+			var v %[1]s
+			if err := v.UnmarshalText(data); err != nil {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if v != replaced(x.Val) {
+				t.Errorf("%%+v: got: %%s want: %%s", x, v, replaced(x.Val))
+			}
+		}
+	})
+	t.Run("UnmarshalText", func(t *testing.T) {
+		for _, x := range tests {
+			data, err := x.Val.MarshalText()
+			if (err == nil) != (x.Valid || lenient) {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if !x.Valid {
+				// Set the data to the string value, which is also invalid.
+				data = []byte(x.Str)
+			}
+
+			var v %[1]s
+			err = v.UnmarshalText(data)
+			if !x.Valid && !lenient {
+				testUnknown(t, err, v, string(data))
+				continue
+			}
+			if err != nil {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if v != replaced(x.Val) {
+				t.Errorf("%%+v: got: %%s want: %%s", x, v, replaced(x.Val))
+			}
+		}
+
+		// invalid values
+		for _, data := range [][]byte{nil, {}} {
+			var v %[1]s
+			if err := v.UnmarshalText(data); err == nil && !emptyValid && !hasFallback {
+				t.Errorf("expected an error unmarshaling: %%v: %%v", data, err)
+			}
+		}
+
+		// Test that we don't include long strings in the error message
+		var v %[1]s
+		invalid := strings.Repeat("a", 256) + "\x00" // this should not collide
+		err := v.UnmarshalText([]byte(invalid))
+		testUnknown(t, err, v, invalid)
+	})
+%[3]s
+}
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: function returning the name of a value in the SQL format
+const testTemplateSQL = `
+	// The names of the values in the SQL format.
+	sqlName := %[2]s
+
+	t.Run("Value", func(t *testing.T) {
+		for _, x := range tests {
+			value, err := x.Val.Value()
+			if (err == nil) != x.Valid {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if !x.Valid {
+				if value != nil {
+					t.Errorf("%%+v: expected nil on error got: %%v", x, value)
+				}
+				exp := invalidValueError(x.Val)
+				if err.Error() != exp {
+					t.Errorf("%%+v: got: %%s want: %%s", x, err.Error(), exp)
+				}
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("%%+v: %%v does not match ErrInvalid", x, err)
+				}
+				continue
+			}
+
+			if value.(string) != sqlName(x.Val) {
+				t.Errorf("%%+v: got: '%%s' want: '%%s'", x, value, sqlName(x.Val))
+			}
+			var v %[1]s
+			if err := v.Scan(value); err != nil {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if v != x.Val {
+				t.Errorf("%%+v: got: %%s want: %%s", x, v, x.Val)
+			}
+		}
+	})
+	t.Run("Scan", func(t *testing.T) {
+		for _, x := range tests {
+			value, err := x.Val.Value()
+			if (err == nil) != x.Valid {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if !x.Valid {
+				// Set the value to the string value, which is also invalid.
+				value = x.Str
+			}
+
+			var v %[1]s
+			err = v.Scan(value)
+			if !x.Valid && !lenient {
+				testUnknown(t, err, v, value.(string))
+				continue
+			}
+			if err != nil {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if v != x.Val {
+				t.Errorf("%%+v: got: %%s want: %%s", x, v, x.Val)
+			}
+		}
+
+		// invalid values
+		for _, data := range []interface{}{nil, []byte{}, 123} {
+			var v %[1]s
+			if _, ok := data.([]byte); ok && (emptyValid || hasFallback) {
+				continue
+			}
+			if err := v.Scan(data); err == nil {
+				t.Errorf("expected an error unmarshaling: %%v: %%v", data, err)
+			}
+		}
+
+		// Test that we don't include long strings in the error message
+		var v %[1]s
+		invalid := strings.Repeat("a", 256) + "\x00" // this should not collide
+		err := v.Scan([]byte(invalid))
+		testUnknown(t, err, v, invalid)
+	})
+`
+
+const testTemplateNoCase = `
+	t.Run("NoCase", func(t *testing.T) {
+		mixedCase := func(s string) string {
+			var b strings.Builder
+			for i, r := range s {
+				if i%%2 == 0 {
+					b.WriteString(strings.ToUpper(string(r)))
+				} else {
+					b.WriteString(strings.ToLower(string(r)))
+				}
+			}
+			return b.String()
+		}
+		for _, x := range tests {
+			if !x.Valid {
+				continue
+			}
+			for _, f := range []func(string) string{strings.ToLower, strings.ToUpper, mixedCase} {
+				var v %[1]s
+				if s := f(x.Str); v.Set(s) != nil || v != x.Val {
+					t.Errorf("%%+v: Set(%%q): got: %%s want: %%s", x, s, v, x.Val)
+				}
+				var u %[1]s
+				if s := f(textName(x.Val)); u.UnmarshalText([]byte(s)) != nil || u != replaced(x.Val) {
+					t.Errorf("%%+v: UnmarshalText(%%q): got: %%s want: %%s", x, s, u, replaced(x.Val))
+				}
+			}
+		}
+	})
+`
+
+// Argument to format is the type name.
+const testTemplateJSON = `
+	t.Run("JSON", func(t *testing.T) {
+		for _, x := range tests {
+			name := textName(x.Val)
+			if !x.Valid || name == "" || name[0] >= 0x80 {
+				continue
+			}
+			// Escape the first character of the name.
+			rest, _ := json.Marshal(name[1:])
+			data := fmt.Sprintf("\"\\u%%04x%%s", name[0], rest[1:])
+			var v %[1]s
+			if err := json.Unmarshal([]byte(data), &v); err != nil || v != replaced(x.Val) {
+				t.Errorf("%%+v: json.Unmarshal(%%s): got: %%s, %%v", x, data, v, err)
+			}
+		}
+		v := tests[0].Val
+		if err := json.Unmarshal([]byte("null"), &v); err != nil || v != tests[0].Val {
+			t.Errorf("json.Unmarshal(null): got: %%s, %%v want: %%s", v, err, tests[0].Val)
+		}
+		if err := json.Unmarshal([]byte("[]"), &v); err == nil {
+			t.Errorf("json.Unmarshal([]): expected an error got: %%s", v)
+		}
+	})
+`
+
+const testTemplateLogValue = `
+	t.Run("LogValue", func(t *testing.T) {
+		for _, x := range tests {
+			v := x.Val.LogValue()
+			if v.Kind() != slog.KindString || v.String() != x.Str {
+				t.Errorf("%+v: got: %s %q want: %q", x, v.Kind(), v, x.Str)
+			}
+		}
+	})
+`
+
+// Argument to format is the integer type of the values, int64 or uint64.
+const testTemplateLogValueGroup = `
+	t.Run("LogValue", func(t *testing.T) {
+		for _, x := range tests {
+			v := x.Val.LogValue()
+			if v.Kind() != slog.KindGroup {
+				t.Errorf("%%+v: got: %%s want: %%s", x, v.Kind(), slog.KindGroup)
+				continue
+			}
+			attrs := v.Group()
+			exp := []slog.Attr{slog.String("name", x.Str), slog.Any("value", %[1]s(x.Val))}
+			if len(attrs) != len(exp) || !attrs[0].Equal(exp[0]) || !attrs[1].Equal(exp[1]) {
+				t.Errorf("%%+v: got: %%v want: %%v", x, attrs, exp)
+			}
+		}
+	})
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: function returning the name of a value in the GraphQL schema
+const testTemplateGraphQL = `
+	t.Run("GraphQL", func(t *testing.T) {
+		graphqlName := %[2]s
+		for _, x := range tests {
+			var b strings.Builder
+			x.Val.MarshalGQL(&b)
+			var v %[1]s
+			if !x.Valid {
+				if want := fmt.Sprintf("%%q", x.Str); b.String() != want {
+					t.Errorf("%%+v: MarshalGQL: got: %%s want: %%s", x, b.String(), want)
+				}
+				err := v.UnmarshalGQL(x.Str)
+				testUnknown(t, err, v, x.Str)
+				continue
+			}
+			if want := fmt.Sprintf("%%q", graphqlName(x.Val)); b.String() != want {
+				t.Errorf("%%+v: MarshalGQL: got: %%s want: %%s", x, b.String(), want)
+			}
+			if err := v.UnmarshalGQL(graphqlName(x.Val)); err != nil || v != x.Val {
+				t.Errorf("%%+v: UnmarshalGQL: got: %%s, %%v want: %%s", x, v, err, x.Val)
+			}
+		}
+		var v %[1]s
+		if err := v.UnmarshalGQL(1); err == nil {
+			t.Error("UnmarshalGQL(1): expected an error")
+		}
+	})
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: integer type of the values, int64 or uint64
+//	[3]: function returning the string returned by GoString
+const testTemplateFormat = `
+	t.Run("Format", func(t *testing.T) {
+		goString := %[3]s
+		for _, x := range tests {
+			n := %[2]s(x.Val)
+			plus := x.Str
+			if x.Valid {
+				plus = "%[1]s(" + x.Str + "=" + fmt.Sprint(n) + ")"
+			}
+			for _, f := range []struct{ format, want string }{
+				{"%%s", x.Str},
+				{"%%v", x.Str},
+				{"%%-16v|", fmt.Sprintf("%%-16s|", x.Str)},
+				{"%%q", fmt.Sprintf("%%q", x.Str)},
+				{"%%d", fmt.Sprint(n)},
+				{"%%+05d", fmt.Sprintf("%%+05d", n)},
+				{"%%#x", fmt.Sprintf("%%#x", n)},
+				{"%%+v", plus},
+				{"%%#v", goString(x.Val)},
+			} {
+				if s := fmt.Sprintf(f.format, x.Val); s != f.want {
+					t.Errorf("%%s: %%s: got: %%q want: %%q", x.Str, f.format, s, f.want)
+				}
+			}
+		}
+	})
+`
+
+// Argument to format is the type name.
+const testTemplateXML = `
+	t.Run("XML", func(t *testing.T) {
+		name := xml.Name{Local: "attr"}
+		for _, x := range tests {
+			attr, err := x.Val.MarshalXMLAttr(name)
+			if (err == nil) != (x.Valid || lenient) {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if !x.Valid && !lenient {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("%%+v: %%v does not match ErrInvalid", x, err)
+				}
+				if _, err := xml.Marshal(x.Val); !errors.Is(err, ErrInvalid) {
+					t.Errorf("%%+v: xml.Marshal: %%v does not match ErrInvalid", x, err)
+				}
+				var v %[1]s
+				err := v.UnmarshalXMLAttr(xml.Attr{Name: name, Value: x.Str})
+				testUnknown(t, err, v, x.Str)
+				continue
+			}
+
+			if attr.Name != name || attr.Value != textName(x.Val) {
+				t.Errorf("%%+v: MarshalXMLAttr: got: %%+v want: '%%s'", x, attr, textName(x.Val))
+			}
+			var v %[1]s
+			if err := v.UnmarshalXMLAttr(attr); err != nil || v != replaced(x.Val) {
+				t.Errorf("%%+v: UnmarshalXMLAttr: got: %%s, %%v want: %%s", x, v, err, replaced(x.Val))
+			}
+
+			data, err := xml.Marshal(x.Val)
+			if err != nil {
+				t.Errorf("%%+v: xml.Marshal: %%v", x, err)
+				continue
+			}
+			var text strings.Builder
+			xml.EscapeText(&text, []byte(textName(x.Val)))
+			if exp := "<%[1]s>" + text.String() + "</%[1]s>"; string(data) != exp {
+				t.Errorf("%%+v: xml.Marshal: got: '%%s' want: '%%s'", x, data, exp)
+			}
+			var u %[1]s
+			if err := xml.Unmarshal(data, &u); err != nil || u != replaced(x.Val) {
+				t.Errorf("%%+v: xml.Unmarshal(%%s): got: %%s, %%v want: %%s", x, data, u, err, replaced(x.Val))
+			}
+		}
+	})
+`
+
+// Argument to format is the type name.
+const testTemplateYAML = `
+	// unmarshalYAML returns a function that unmarshals the scalar s, as the
+	// yaml packages do.
+	unmarshalYAML := func(s string) func(interface{}) error {
+		return func(v interface{}) error {
+			*v.(*string) = s
+			return nil
+		}
+	}
+
+	t.Run("MarshalYAML", func(t *testing.T) {
+		for _, x := range tests {
+			value, err := x.Val.MarshalYAML()
+			if (err == nil) != (x.Valid || lenient) {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if !x.Valid && !lenient {
+				if value != nil {
+					t.Errorf("%%+v: expected nil on error got: %%v", x, value)
+				}
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("%%+v: %%v does not match ErrInvalid", x, err)
+				}
+				continue
+			}
+
+			s := fmt.Sprint(value)
+			if s != textName(x.Val) {
+				t.Errorf("%%+v: got: '%%s' want: '%%s'", x, s, textName(x.Val))
+			}
+			var v %[1]s
+			if err := v.UnmarshalYAML(unmarshalYAML(s)); err != nil || v != replaced(x.Val) {
+				t.Errorf("%%+v: UnmarshalYAML: got: %%s, %%v want: %%s", x, v, err, replaced(x.Val))
+			}
+		}
+	})
+	t.Run("UnmarshalYAML", func(t *testing.T) {
+		for _, x := range tests {
+			if x.Valid || lenient {
+				continue
+			}
+			var v %[1]s
+			err := v.UnmarshalYAML(unmarshalYAML(x.Str))
+			testUnknown(t, err, v, x.Str)
+		}
+
+		// The errors of the yaml package are returned.
+		exp := errors.New("yaml: cannot unmarshal a sequence into a string")
+		var v %[1]s
+		if err := v.UnmarshalYAML(func(interface{}) error { return exp }); err != exp {
+			t.Errorf("got: %%v want: %%v", err, exp)
+		}
+	})
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: expression encoding any value v as a varint
+const testTemplateBinary = `
+	encodeBinary := func(v %[1]s) []byte {
+		return %[2]s
+	}
+
+	t.Run("MarshalBinary", func(t *testing.T) {
+		for _, x := range tests {
+			data, err := x.Val.MarshalBinary()
+			if (err == nil) != (x.Valid || lenient) {
+				t.Errorf("%%+v: %%v", x, err)
+				continue
+			}
+			if !x.Valid && !lenient {
+				if data != nil {
+					t.Errorf("%%+v: expected []byte(nil) on error got: %%v", x, data)
+				}
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("%%+v: %%v does not match ErrInvalid", x, err)
+				}
+				continue
+			}
+
+			if exp := encodeBinary(x.Val); string(data) != string(exp) {
+				t.Errorf("%%+v: got: %%v want: %%v", x, data, exp)
+			}
+			if b, err := x.Val.AppendBinary([]byte("prefix")); err != nil || string(b) != "prefix"+string(data) {
+				t.Errorf("%%+v: AppendBinary: got: %%q, %%v", x, b, err)
+			}
+			var v %[1]s
+			if err := v.UnmarshalBinary(data); err != nil || v != x.Val {
+				t.Errorf("%%+v: UnmarshalBinary: got: %%s, %%v", x, v, err)
+			}
+		}
+	})
+	t.Run("UnmarshalBinary", func(t *testing.T) {
+		for _, x := range tests {
+			var v %[1]s
+			err := v.UnmarshalBinary(encodeBinary(x.Val))
+			if x.Valid || lenient {
+				if err != nil || v != x.Val {
+					t.Errorf("%%+v: got: %%s, %%v", x, v, err)
+				}
+				continue
+			}
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("%%+v: %%v does not match ErrInvalid", x, err)
+			}
+		}
+
+		// malformed varints
+		for _, data := range [][]byte{nil, {0x80}, {0, 0}} {
+			var v %[1]s
+			err := v.UnmarshalBinary(data)
+			if !errors.Is(err, ErrMalformed) {
+				t.Errorf("%%v: got: %%v want: ErrMalformed", data, err)
+			}
+			var perr *ParseError
+			if errors.As(err, &perr) && perr.Input != fmt.Sprintf("%%x", data) {
+				t.Errorf("%%v: Input: got: %%q want: %%x", data, perr.Input, data)
+			}
+		}
+	})
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: integer type of the bare numbers, int64 or uint64
+const testTemplateLenient = `
+	t.Run("Lenient", func(t *testing.T) {
+		for _, x := range tests {
+			// The integer is parsed as a name if there is one.
+			s := fmt.Sprint(%[2]s(x.Val))
+			var v %[1]s
+			if err := v.Set(s); err != nil || v != x.Val && v.String() != s {
+				t.Errorf("%%+v: Set(%%q): got: %%s, %%v", x, s, v, err)
+			}
+			var u %[1]s
+			if err := u.UnmarshalText([]byte(s)); err != nil || u != x.Val && textName(u) != s {
+				t.Errorf("%%+v: UnmarshalText(%%q): got: %%s, %%v", x, s, u, err)
+			}
+		}
+		for _, s := range []string{"", "%[1]s()", "%[1]s(1", "(1)", "1.5", "0x1"} {
+			var v %[1]s
+			if v.Set(s) == nil && v.String() != s {
+				t.Errorf("Set(%%q): expected an error got: %%s", s, v)
+			}
+		}
+	})
+`
+
+const testTemplateValues = `
+	t.Run("Values", func(t *testing.T) {
+		values := %[1]sValues()
+		names := %[1]sNames()
+		if len(values) != %[1]sCount || len(names) != %[1]sCount {
+			t.Fatalf("Count: got: %%d values and %%d names want: %%d", len(values), len(names), %[1]sCount)
+		}
+		for i, v := range values {
+			if !v.Valid() {
+				t.Errorf("%%d: invalid value: %%s", i, v)
+			}
+			if i > 0 && values[i-1] == v {
+				t.Errorf("%%d: duplicate value: %%s", i, v)
+			}
+			if n := v.Index(); n != i {
+				t.Errorf("%%s: Index: got: %%d want: %%d", v, n, i)
+			}
+			if x, ok := %[1]sFromIndex(i); !ok || x != v {
+				t.Errorf("%%s: FromIndex(%%d): got: %%s, %%t", v, i, x, ok)
+			}
+			if names[i] != v.String() {
+				t.Errorf("%%s: Names: got: %%q want: %%q", v, names[i], v.String())
+			}
+		}
+		for _, x := range tests {
+			n := x.Val.Index()
+			if n >= 0 && values[n] != x.Val || !x.Valid && n != -1 {
+				t.Errorf("%%+v: Index: got: %%d", x, n)
+			}
+		}
+		for _, n := range []int{-1, %[1]sCount} {
+			if x, ok := %[1]sFromIndex(n); ok {
+				t.Errorf("FromIndex(%%d): got: %%s, %%t want: false", n, x, ok)
+			}
+		}
+		// The returned slices must be copies.
+		names[0] = ""
+		if &%[1]sValues()[0] == &values[0] {
+			t.Errorf("Values: modifying the result changed the values")
+		}
+		if s := %[1]sNames()[0]; s == "" {
+			t.Errorf("Names: modifying the result changed the names")
+		}
+	})
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: aliases to test
+const testTemplateAliases = `
+	t.Run("Aliases", func(t *testing.T) {
+		var aliases = []struct {
+			Val  %[1]s
+			Str  string
+			Text string
+		}{
+%[2]s		}
+		for _, x := range aliases {
+			var v %[1]s
+			if err := v.Set(x.Str); err != nil || v != x.Val {
+				t.Errorf("%%+v: Set: got: %%s, %%v want: %%s", x, v, err, x.Val)
+			}
+			var u %[1]s
+			if err := u.UnmarshalText([]byte(x.Text)); err != nil || u != x.Val {
+				t.Errorf("%%+v: UnmarshalText: got: %%s, %%v want: %%s", x, u, err, x.Val)
+			}
+		}
+	})
+`
+
+const testTemplateBitmask = `
+	t.Run("Flags", func(t *testing.T) {
+		for _, x := range tests {
+			var v %[1]s
+			for _, f := range x.Val.Flags() {
+				if !x.Val.Has(f) {
+					t.Errorf("%%+v: Has(%%s) = false", x, f)
+				}
+				v = v.SetFlag(f)
+			}
+			if x.Valid && v != x.Val {
+				t.Errorf("%%+v: Flags: got: %%s want: %%s", x, v, x.Val)
+			}
+			if rest := x.Val.ClearFlag(v); rest&v != 0 || (rest != 0) == x.Valid {
+				t.Errorf("%%+v: ClearFlag(%%s): got: %%s", x, v, rest)
+			}
+		}
+	})
+`
+
+// Arguments to format are:
+//	[1]: type name
+//	[2]: valid values to benchmark with
+//	[3]: additional benchmarks
+const benchmarkTemplate = `
+func BenchmarkGeneratedEnum_%[1]s(b *testing.B) {
+	var tests = [...]struct {
+		Val   %[1]s
+		Str   string
+		Bytes []byte
+	}{
+%[2]s
+	}
+	b.ResetTimer()
+	b.Run("Valid", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			t := tests[i%%len(tests)]
+			t.Val.Valid()
+		}
+	})
+	b.Run("String", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			t := tests[i%%len(tests)]
+			t.Val.String()
+		}
+	})
+	b.Run("Set", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			t := tests[i%%len(tests)]
+			t.Val.Set(t.Str)
+		}
+	})
+	b.Run("MarshalText", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			t := tests[i%%len(tests)]
+			t.Val.MarshalText()
+		}
+	})
+	b.Run("UnmarshalText", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			t := tests[i%%len(tests)]
+			t.Val.UnmarshalText(t.Bytes)
+		}
+	})
+%[3]s
+}
+`
+
+// Argument to format is the type name.
+const benchmarkTemplateJSON = `
+	b.Run("MarshalJSON", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			t := tests[i%%len(tests)]
+			t.Val.MarshalJSON()
+		}
+	})
+	b.Run("UnmarshalJSON", func(b *testing.B) {
+		var data [len(tests)][]byte
+		for i, t := range tests {
+			data[i], _ = t.Val.MarshalJSON()
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var v %[1]s
+			v.UnmarshalJSON(data[i%%len(data)])
+		}
+	})
+`
+
+const benchmarkTemplateSQL = `
+	b.Run("Value", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			t := tests[i%%len(tests)]
+			t.Val.Value()
+		}
+	})
+	b.Run("Scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			t := tests[i%%len(tests)]
+			t.Val.Scan(t.Bytes)
+		}
+	})
+`
@@ -0,0 +1,3151 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains simple golden tests for various examples.
+// Besides validating the results when the implementation changes,
+// it provides a way to look at the generated code without having
+// to execute the print statements in one's head.
+
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charlievieth/go-enum/internal/testenv"
+)
+
+// Golden represents a test case.
+type Golden struct {
+	name   string
+	opts   Options
+	input  string // input; the package clause is provided when running the test.
+	output string // expected output.
+}
+
+var golden = []Golden{
+	{"day", Options{}, day_in, day_out},
+	{"offset", Options{}, offset_in, offset_out},
+	{"gap", Options{}, gap_in, gap_out},
+	{"num", Options{}, num_in, num_out},
+	{"unum", Options{}, unum_in, unum_out},
+	{"unumpos", Options{}, unumpos_in, unumpos_out},
+	{"prime", Options{}, prime_in, prime_out},
+	{"search", Options{Sparse: "search", Values: true}, prime_in, search_out},
+	{"prefix", Options{TrimPrefix: "Type"}, prefix_in, prefix_out},
+	{"transform", Options{TrimPrefix: "Status", Transform: "snake", LineComment: true}, transform_in, transform_out},
+	{"tags", Options{SQL: true, LineComment: true}, tags_in, tags_out},
+	{"aliasdir", Options{Lookup: "map"}, aliasdir_in, aliasdir_out},
+	{"exclude", Options{Exclude: "^num"}, exclude_in, exclude_out},
+	{"deprecated", Options{Values: true, ReplaceDeprecated: true}, deprecated_in, deprecated_out},
+	{"fallback", Options{SQL: true}, fallback_in, fallback_out},
+	{"lenient", Options{Lenient: true, NoCase: true}, lenient_in, lenient_out},
+	{"encoding", Options{Encoding: "number"}, encoding_in, encoding_out},
+	{"json", Options{JSON: true}, json_in, json_out},
+	{"binary", Options{Binary: true}, binary_in, binary_out},
+	{"yaml", Options{YAML: true, Encoding: "number"}, yaml_in, yaml_out},
+	{"xml", Options{XML: true}, xml_in, xml_out},
+	{"slog", Options{Slog: "group"}, slog_in, slog_out},
+	{"format", Options{Formatter: true}, format_in, format_out},
+	{"graphql", Options{GraphQL: true}, graphql_in, graphql_out},
+	{"tokens", Options{LineComment: true}, tokens_in, tokens_out},
+	{"perm", Options{Bitmask: true}, perm_in, perm_out},
+	{"nocase", Options{NoCase: true}, nocase_in, nocase_out},
+	{"values", Options{Values: true}, values_in, values_out},
+	{"color", Options{}, color_in, color_out},
+	{"primary", Options{}, primary_in, primary_out},
+	{"sentinel", Options{}, sentinel_in, sentinel_out},
+	{"phash", Options{Lookup: "phash"}, phash_in, phash_out},
+}
+
+// Each example starts with "type XXX [u]int", with a single space separating them.
+
+// Simple test: enumeration of type int starting at 0.
+const day_in = `type Day int
+const (
+	Monday Day = iota
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+	Sunday
+)
+`
+
+const day_out = `
+var _Day_names = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+const _Day_name = "MondayTuesdayWednesdayThursdayFridaySaturdaySunday"
+
+var _Day_index = [...]uint8{0, 6, 13, 22, 30, 36, 44, 50}
+
+func (i Day) String() string {
+	if i < 0 || i >= Day(len(_Day_index)-1) {
+		return "Day(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Day_name[_Day_index[i]:_Day_index[i+1]]
+}
+
+func (i Day) Valid() bool {
+	return !(i < 0 || i >= Day(len(_Day_index)-1))
+}
+
+func (i Day) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Day(len(_Day_index)-1) {
+		return nil, &InvalidValueError{Type: "Day", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Day_name[_Day_index[i]:_Day_index[i+1]]), nil
+}
+
+func (i *Day) Set(s string) (err error) {
+	switch s {
+	case _Day_name[0:6]:
+		*i = Monday
+	case _Day_name[6:13]:
+		*i = Tuesday
+	case _Day_name[13:22]:
+		*i = Wednesday
+	case _Day_name[22:30]:
+		*i = Thursday
+	case _Day_name[30:36]:
+		*i = Friday
+	case _Day_name[36:44]:
+		*i = Saturday
+	case _Day_name[44:50]:
+		*i = Sunday
+	default:
+		err = &ParseError{Type: "Day", Input: s, names: _Day_names}
+	}
+	return err
+}
+
+func (i *Day) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Day_name[0:6]:
+		*i = Monday
+	case _Day_name[6:13]:
+		*i = Tuesday
+	case _Day_name[13:22]:
+		*i = Wednesday
+	case _Day_name[22:30]:
+		*i = Thursday
+	case _Day_name[30:36]:
+		*i = Friday
+	case _Day_name[36:44]:
+		*i = Saturday
+	case _Day_name[44:50]:
+		*i = Sunday
+	default:
+		err = &ParseError{Type: "Day", Input: string(s), names: _Day_names}
+	}
+	return err
+}
+`
+
+// Enumeration with an offset.
+// Also includes a duplicate.
+const offset_in = `type Number int
+const (
+	_ Number = iota
+	One
+	Two
+	Three
+	AnotherOne = One  // Duplicate; AnotherOne is only accepted by the unmarshal methods.
+)
+`
+
+const offset_out = `
+var _Number_names = []string{"One", "Two", "Three"}
+
+const _Number_name = "OneTwoThree"
+
+var _Number_index = [...]uint8{0, 3, 6, 11}
+
+func (i Number) String() string {
+	i -= 1
+	if i < 0 || i >= Number(len(_Number_index)-1) {
+		return "Number(" + strconv.FormatInt(int64(i+1), 10) + ")"
+	}
+	return _Number_name[_Number_index[i]:_Number_index[i+1]]
+}
+
+func (i Number) Valid() bool {
+	i -= 1
+	return !(i < 0 || i >= Number(len(_Number_index)-1))
+}
+
+func (i Number) MarshalText() ([]byte, error) {
+	i -= 1
+	if i < 0 || i >= Number(len(_Number_index)-1) {
+		return nil, &InvalidValueError{Type: "Number", Value: strconv.FormatInt(int64(i+1), 10)}
+	}
+	return []byte(_Number_name[_Number_index[i]:_Number_index[i+1]]), nil
+}
+
+func (i *Number) Set(s string) (err error) {
+	switch s {
+	case _Number_name[0:3]:
+		*i = One
+	case _Number_name[3:6]:
+		*i = Two
+	case _Number_name[6:11]:
+		*i = Three
+	case "AnotherOne":
+		*i = One
+	default:
+		err = &ParseError{Type: "Number", Input: s, names: _Number_names}
+	}
+	return err
+}
+
+func (i *Number) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Number_name[0:3]:
+		*i = One
+	case _Number_name[3:6]:
+		*i = Two
+	case _Number_name[6:11]:
+		*i = Three
+	case "AnotherOne":
+		*i = One
+	default:
+		err = &ParseError{Type: "Number", Input: string(s), names: _Number_names}
+	}
+	return err
+}
+`
+
+// Gaps and an offset.
+const gap_in = `type Gap int
+const (
+	Two Gap = 2
+	Three Gap = 3
+	Five Gap = 5
+	Six Gap = 6
+	Seven Gap = 7
+	Eight Gap = 8
+	Nine Gap = 9
+	Eleven Gap = 11
+)
+`
+
+const gap_out = `
+var _Gap_names = []string{"Two", "Three", "Five", "Six", "Seven", "Eight", "Nine", "Eleven"}
+
+const (
+	_Gap_name_0 = "TwoThree"
+	_Gap_name_1 = "FiveSixSevenEightNine"
+	_Gap_name_2 = "Eleven"
+)
+
+var (
+	_Gap_index_0 = [...]uint8{0, 3, 8}
+	_Gap_index_1 = [...]uint8{0, 4, 7, 12, 17, 21}
+)
+
+func (i Gap) String() string {
+	switch {
+	case 2 <= i && i <= 3:
+		i -= 2
+		return _Gap_name_0[_Gap_index_0[i]:_Gap_index_0[i+1]]
+	case 5 <= i && i <= 9:
+		i -= 5
+		return _Gap_name_1[_Gap_index_1[i]:_Gap_index_1[i+1]]
+	case i == 11:
+		return _Gap_name_2
+	default:
+		return "Gap(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+
+func (i Gap) Valid() bool {
+	switch {
+	case 2 <= i && i <= 3:
+	case 5 <= i && i <= 9:
+	case i == 11:
+	default:
+		return false
+	}
+	return true
+}
+
+func (i Gap) MarshalText() ([]byte, error) {
+	if i.Valid() {
+		return []byte(i.String()), nil
+	}
+	return nil, &InvalidValueError{Type: "Gap", Value: strconv.FormatInt(int64(i), 10)}
+}
+
+func (i *Gap) Set(s string) (err error) {
+	switch s {
+	case _Gap_name_0[0:3]:
+		*i = Two
+	case _Gap_name_0[3:8]:
+		*i = Three
+	case _Gap_name_1[0:4]:
+		*i = Five
+	case _Gap_name_1[4:7]:
+		*i = Six
+	case _Gap_name_1[7:12]:
+		*i = Seven
+	case _Gap_name_1[12:17]:
+		*i = Eight
+	case _Gap_name_1[17:21]:
+		*i = Nine
+	case _Gap_name_2:
+		*i = Eleven
+	default:
+		err = &ParseError{Type: "Gap", Input: s, names: _Gap_names}
+	}
+	return err
+}
+
+func (i *Gap) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Gap_name_0[0:3]:
+		*i = Two
+	case _Gap_name_0[3:8]:
+		*i = Three
+	case _Gap_name_1[0:4]:
+		*i = Five
+	case _Gap_name_1[4:7]:
+		*i = Six
+	case _Gap_name_1[7:12]:
+		*i = Seven
+	case _Gap_name_1[12:17]:
+		*i = Eight
+	case _Gap_name_1[17:21]:
+		*i = Nine
+	case _Gap_name_2:
+		*i = Eleven
+	default:
+		err = &ParseError{Type: "Gap", Input: string(s), names: _Gap_names}
+	}
+	return err
+}
+`
+
+// Signed integers spanning zero.
+const num_in = `type Num int
+const (
+	m_2 Num = -2 + iota
+	m_1
+	m0
+	m1
+	m2
+)
+`
+
+const num_out = `
+var _Num_names = []string{"m_2", "m_1", "m0", "m1", "m2"}
+
+const _Num_name = "m_2m_1m0m1m2"
+
+var _Num_index = [...]uint8{0, 3, 6, 8, 10, 12}
+
+func (i Num) String() string {
+	i -= -2
+	if i < 0 || i >= Num(len(_Num_index)-1) {
+		return "Num(" + strconv.FormatInt(int64(i+-2), 10) + ")"
+	}
+	return _Num_name[_Num_index[i]:_Num_index[i+1]]
+}
+
+func (i Num) Valid() bool {
+	i -= -2
+	return !(i < 0 || i >= Num(len(_Num_index)-1))
+}
+
+func (i Num) MarshalText() ([]byte, error) {
+	i -= -2
+	if i < 0 || i >= Num(len(_Num_index)-1) {
+		return nil, &InvalidValueError{Type: "Num", Value: strconv.FormatInt(int64(i+-2), 10)}
+	}
+	return []byte(_Num_name[_Num_index[i]:_Num_index[i+1]]), nil
+}
+
+func (i *Num) Set(s string) (err error) {
+	switch s {
+	case _Num_name[0:3]:
+		*i = m_2
+	case _Num_name[3:6]:
+		*i = m_1
+	case _Num_name[6:8]:
+		*i = m0
+	case _Num_name[8:10]:
+		*i = m1
+	case _Num_name[10:12]:
+		*i = m2
+	default:
+		err = &ParseError{Type: "Num", Input: s, names: _Num_names}
+	}
+	return err
+}
+
+func (i *Num) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Num_name[0:3]:
+		*i = m_2
+	case _Num_name[3:6]:
+		*i = m_1
+	case _Num_name[6:8]:
+		*i = m0
+	case _Num_name[8:10]:
+		*i = m1
+	case _Num_name[10:12]:
+		*i = m2
+	default:
+		err = &ParseError{Type: "Num", Input: string(s), names: _Num_names}
+	}
+	return err
+}
+`
+
+// Unsigned integers spanning zero.
+const unum_in = `type Unum uint
+const (
+	m_2 Unum = iota + 253
+	m_1
+)
+
+const (
+	m0 Unum = iota
+	m1
+	m2
+)
+`
+
+const unum_out = `
+var _Unum_names = []string{"m_2", "m_1", "m0", "m1", "m2"}
+
+const (
+	_Unum_name_0 = "m0m1m2"
+	_Unum_name_1 = "m_2m_1"
+)
+
+var (
+	_Unum_index_0 = [...]uint8{0, 2, 4, 6}
+	_Unum_index_1 = [...]uint8{0, 3, 6}
+)
+
+func (i Unum) String() string {
+	switch {
+	case i <= 2:
+		return _Unum_name_0[_Unum_index_0[i]:_Unum_index_0[i+1]]
+	case 253 <= i && i <= 254:
+		i -= 253
+		return _Unum_name_1[_Unum_index_1[i]:_Unum_index_1[i+1]]
+	default:
+		return "Unum(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+
+func (i Unum) Valid() bool {
+	switch {
+	case i <= 2:
+	case 253 <= i && i <= 254:
+	default:
+		return false
+	}
+	return true
+}
+
+func (i Unum) MarshalText() ([]byte, error) {
+	if i.Valid() {
+		return []byte(i.String()), nil
+	}
+	return nil, &InvalidValueError{Type: "Unum", Value: strconv.FormatInt(int64(i), 10)}
+}
+
+func (i *Unum) Set(s string) (err error) {
+	switch s {
+	case _Unum_name_0[0:2]:
+		*i = m0
+	case _Unum_name_0[2:4]:
+		*i = m1
+	case _Unum_name_0[4:6]:
+		*i = m2
+	case _Unum_name_1[0:3]:
+		*i = m_2
+	case _Unum_name_1[3:6]:
+		*i = m_1
+	default:
+		err = &ParseError{Type: "Unum", Input: s, names: _Unum_names}
+	}
+	return err
+}
+
+func (i *Unum) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Unum_name_0[0:2]:
+		*i = m0
+	case _Unum_name_0[2:4]:
+		*i = m1
+	case _Unum_name_0[4:6]:
+		*i = m2
+	case _Unum_name_1[0:3]:
+		*i = m_2
+	case _Unum_name_1[3:6]:
+		*i = m_1
+	default:
+		err = &ParseError{Type: "Unum", Input: string(s), names: _Unum_names}
+	}
+	return err
+}
+`
+
+// Unsigned positive integers.
+const unumpos_in = `type Unumpos uint
+const (
+	m253 Unumpos = iota + 253
+	m254
+)
+
+const (
+	m1 Unumpos = iota + 1
+	m2
+	m3
+)
+`
+
+const unumpos_out = `
+var _Unumpos_names = []string{"m253", "m254", "m1", "m2", "m3"}
+
+const (
+	_Unumpos_name_0 = "m1m2m3"
+	_Unumpos_name_1 = "m253m254"
+)
+
+var (
+	_Unumpos_index_0 = [...]uint8{0, 2, 4, 6}
+	_Unumpos_index_1 = [...]uint8{0, 4, 8}
+)
+
+func (i Unumpos) String() string {
+	switch {
+	case 1 <= i && i <= 3:
+		i -= 1
+		return _Unumpos_name_0[_Unumpos_index_0[i]:_Unumpos_index_0[i+1]]
+	case 253 <= i && i <= 254:
+		i -= 253
+		return _Unumpos_name_1[_Unumpos_index_1[i]:_Unumpos_index_1[i+1]]
+	default:
+		return "Unumpos(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+
+func (i Unumpos) Valid() bool {
+	switch {
+	case 1 <= i && i <= 3:
+	case 253 <= i && i <= 254:
+	default:
+		return false
+	}
+	return true
+}
+
+func (i Unumpos) MarshalText() ([]byte, error) {
+	if i.Valid() {
+		return []byte(i.String()), nil
+	}
+	return nil, &InvalidValueError{Type: "Unumpos", Value: strconv.FormatInt(int64(i), 10)}
+}
+
+func (i *Unumpos) Set(s string) (err error) {
+	switch s {
+	case _Unumpos_name_0[0:2]:
+		*i = m1
+	case _Unumpos_name_0[2:4]:
+		*i = m2
+	case _Unumpos_name_0[4:6]:
+		*i = m3
+	case _Unumpos_name_1[0:4]:
+		*i = m253
+	case _Unumpos_name_1[4:8]:
+		*i = m254
+	default:
+		err = &ParseError{Type: "Unumpos", Input: s, names: _Unumpos_names}
+	}
+	return err
+}
+
+func (i *Unumpos) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Unumpos_name_0[0:2]:
+		*i = m1
+	case _Unumpos_name_0[2:4]:
+		*i = m2
+	case _Unumpos_name_0[4:6]:
+		*i = m3
+	case _Unumpos_name_1[0:4]:
+		*i = m253
+	case _Unumpos_name_1[4:8]:
+		*i = m254
+	default:
+		err = &ParseError{Type: "Unumpos", Input: string(s), names: _Unumpos_names}
+	}
+	return err
+}
+`
+
+// Enough gaps to trigger a map implementation of the method.
+// Also includes a duplicate to test that it doesn't cause problems
+const prime_in = `type Prime int
+const (
+	p2 Prime = 2
+	p3 Prime = 3
+	p5 Prime = 5
+	p7 Prime = 7
+	// Skip duplicates: we don't support them
+	// p77 Prime = 7 // Duplicate; note that p77 doesn't appear below.
+	p11 Prime = 11
+	p13 Prime = 13
+	p17 Prime = 17
+	p19 Prime = 19
+	p23 Prime = 23
+	p29 Prime = 29
+	p37 Prime = 31
+	p41 Prime = 41
+	p43 Prime = 43
+)
+`
+
+const prime_out = `
+var _Prime_names = []string{"p2", "p3", "p5", "p7", "p11", "p13", "p17", "p19", "p23", "p29", "p37", "p41", "p43"}
+
+const _Prime_name = "p2p3p5p7p11p13p17p19p23p29p37p41p43"
+
+var _Prime_map = map[Prime]string{
+	2:  _Prime_name[0:2],
+	3:  _Prime_name[2:4],
+	5:  _Prime_name[4:6],
+	7:  _Prime_name[6:8],
+	11: _Prime_name[8:11],
+	13: _Prime_name[11:14],
+	17: _Prime_name[14:17],
+	19: _Prime_name[17:20],
+	23: _Prime_name[20:23],
+	29: _Prime_name[23:26],
+	31: _Prime_name[26:29],
+	41: _Prime_name[29:32],
+	43: _Prime_name[32:35],
+}
+
+func (i Prime) String() string {
+	if str, ok := _Prime_map[i]; ok {
+		return str
+	}
+	return "Prime(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+
+func (i Prime) Valid() bool {
+	_, ok := _Prime_map[i]
+	return ok
+}
+
+func (i Prime) MarshalText() ([]byte, error) {
+	if str, ok := _Prime_map[i]; ok {
+		return []byte(str), nil
+	}
+	return nil, &InvalidValueError{Type: "Prime", Value: strconv.FormatInt(int64(i), 10)}
+}
+
+func (i *Prime) Set(s string) (err error) {
+	switch s {
+	case _Prime_name[0:2]:
+		*i = p2
+	case _Prime_name[2:4]:
+		*i = p3
+	case _Prime_name[4:6]:
+		*i = p5
+	case _Prime_name[6:8]:
+		*i = p7
+	case _Prime_name[8:11]:
+		*i = p11
+	case _Prime_name[11:14]:
+		*i = p13
+	case _Prime_name[14:17]:
+		*i = p17
+	case _Prime_name[17:20]:
+		*i = p19
+	case _Prime_name[20:23]:
+		*i = p23
+	case _Prime_name[23:26]:
+		*i = p29
+	case _Prime_name[26:29]:
+		*i = p37
+	case _Prime_name[29:32]:
+		*i = p41
+	case _Prime_name[32:35]:
+		*i = p43
+	default:
+		err = &ParseError{Type: "Prime", Input: s, names: _Prime_names}
+	}
+	return err
+}
+
+func (i *Prime) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Prime_name[0:2]:
+		*i = p2
+	case _Prime_name[2:4]:
+		*i = p3
+	case _Prime_name[4:6]:
+		*i = p5
+	case _Prime_name[6:8]:
+		*i = p7
+	case _Prime_name[8:11]:
+		*i = p11
+	case _Prime_name[11:14]:
+		*i = p13
+	case _Prime_name[14:17]:
+		*i = p17
+	case _Prime_name[17:20]:
+		*i = p19
+	case _Prime_name[20:23]:
+		*i = p23
+	case _Prime_name[23:26]:
+		*i = p29
+	case _Prime_name[26:29]:
+		*i = p37
+	case _Prime_name[29:32]:
+		*i = p41
+	case _Prime_name[32:35]:
+		*i = p43
+	default:
+		err = &ParseError{Type: "Prime", Input: string(s), names: _Prime_names}
+	}
+	return err
+}
+`
+
+const search_out = `
+var _Prime_names = []string{"p2", "p3", "p5", "p7", "p11", "p13", "p17", "p19", "p23", "p29", "p37", "p41", "p43"}
+
+const _Prime_name = "p2p3p5p7p11p13p17p19p23p29p37p41p43"
+
+var _Prime_index = [...]uint8{0, 2, 4, 6, 8, 11, 14, 17, 20, 23, 26, 29, 32, 35}
+var _Prime_values = [...]Prime{p2, p3, p5, p7, p11, p13, p17, p19, p23, p29, p37, p41, p43}
+
+func (i Prime) String() string {
+	if n := _Prime_search(i); n >= 0 {
+		return _Prime_name[_Prime_index[n]:_Prime_index[n+1]]
+	}
+	return "Prime(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+
+// _Prime_search returns the position of i in _Prime_values or -1.
+func _Prime_search(i Prime) int {
+	lo, hi := 0, len(_Prime_values)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if _Prime_values[m] < i {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	if lo < len(_Prime_values) && _Prime_values[lo] == i {
+		return lo
+	}
+	return -1
+}
+
+func (i Prime) Valid() bool {
+	return _Prime_search(i) >= 0
+}
+
+func (i Prime) MarshalText() ([]byte, error) {
+	if n := _Prime_search(i); n >= 0 {
+		return []byte(_Prime_name[_Prime_index[n]:_Prime_index[n+1]]), nil
+	}
+	return nil, &InvalidValueError{Type: "Prime", Value: strconv.FormatInt(int64(i), 10)}
+}
+
+func (i *Prime) Set(s string) (err error) {
+	switch s {
+	case _Prime_name[0:2]:
+		*i = p2
+	case _Prime_name[2:4]:
+		*i = p3
+	case _Prime_name[4:6]:
+		*i = p5
+	case _Prime_name[6:8]:
+		*i = p7
+	case _Prime_name[8:11]:
+		*i = p11
+	case _Prime_name[11:14]:
+		*i = p13
+	case _Prime_name[14:17]:
+		*i = p17
+	case _Prime_name[17:20]:
+		*i = p19
+	case _Prime_name[20:23]:
+		*i = p23
+	case _Prime_name[23:26]:
+		*i = p29
+	case _Prime_name[26:29]:
+		*i = p37
+	case _Prime_name[29:32]:
+		*i = p41
+	case _Prime_name[32:35]:
+		*i = p43
+	default:
+		err = &ParseError{Type: "Prime", Input: s, names: _Prime_names}
+	}
+	return err
+}
+
+func (i *Prime) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Prime_name[0:2]:
+		*i = p2
+	case _Prime_name[2:4]:
+		*i = p3
+	case _Prime_name[4:6]:
+		*i = p5
+	case _Prime_name[6:8]:
+		*i = p7
+	case _Prime_name[8:11]:
+		*i = p11
+	case _Prime_name[11:14]:
+		*i = p13
+	case _Prime_name[14:17]:
+		*i = p17
+	case _Prime_name[17:20]:
+		*i = p19
+	case _Prime_name[20:23]:
+		*i = p23
+	case _Prime_name[23:26]:
+		*i = p29
+	case _Prime_name[26:29]:
+		*i = p37
+	case _Prime_name[29:32]:
+		*i = p41
+	case _Prime_name[32:35]:
+		*i = p43
+	default:
+		err = &ParseError{Type: "Prime", Input: string(s), names: _Prime_names}
+	}
+	return err
+}
+
+const PrimeCount = 13
+
+func PrimeValues() []Prime {
+	values := _Prime_values
+	return values[:]
+}
+
+func PrimeNames() []string {
+	names := make([]string, len(_Prime_values))
+	for i, v := range _Prime_values {
+		names[i] = v.String()
+	}
+	return names
+}
+
+func PrimeFromIndex(n int) (Prime, bool) {
+	if n < 0 || n >= len(_Prime_values) {
+		return 0, false
+	}
+	return _Prime_values[n], true
+}
+
+func (i Prime) Index() int {
+	return _Prime_search(i)
+}
+`
+
+const prefix_in = `type Type int
+const (
+	TypeInt Type = iota
+	TypeString
+	TypeFloat
+	TypeRune
+	TypeByte
+	TypeStruct
+	TypeSlice
+)
+`
+
+const prefix_out = `
+var _Type_names = []string{"Int", "String", "Float", "Rune", "Byte", "Struct", "Slice"}
+
+const _Type_name = "IntStringFloatRuneByteStructSlice"
+
+var _Type_index = [...]uint8{0, 3, 9, 14, 18, 22, 28, 33}
+
+func (i Type) String() string {
+	if i < 0 || i >= Type(len(_Type_index)-1) {
+		return "Type(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Type_name[_Type_index[i]:_Type_index[i+1]]
+}
+
+func (i Type) Valid() bool {
+	return !(i < 0 || i >= Type(len(_Type_index)-1))
+}
+
+func (i Type) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Type(len(_Type_index)-1) {
+		return nil, &InvalidValueError{Type: "Type", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Type_name[_Type_index[i]:_Type_index[i+1]]), nil
+}
+
+func (i *Type) Set(s string) (err error) {
+	switch s {
+	case _Type_name[0:3]:
+		*i = TypeInt
+	case _Type_name[3:9]:
+		*i = TypeString
+	case _Type_name[9:14]:
+		*i = TypeFloat
+	case _Type_name[14:18]:
+		*i = TypeRune
+	case _Type_name[18:22]:
+		*i = TypeByte
+	case _Type_name[22:28]:
+		*i = TypeStruct
+	case _Type_name[28:33]:
+		*i = TypeSlice
+	default:
+		err = &ParseError{Type: "Type", Input: s, names: _Type_names}
+	}
+	return err
+}
+
+func (i *Type) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Type_name[0:3]:
+		*i = TypeInt
+	case _Type_name[3:9]:
+		*i = TypeString
+	case _Type_name[9:14]:
+		*i = TypeFloat
+	case _Type_name[14:18]:
+		*i = TypeRune
+	case _Type_name[18:22]:
+		*i = TypeByte
+	case _Type_name[22:28]:
+		*i = TypeStruct
+	case _Type_name[28:33]:
+		*i = TypeSlice
+	default:
+		err = &ParseError{Type: "Type", Input: string(s), names: _Type_names}
+	}
+	return err
+}
+`
+
+// Names transformed to snake case after trimming the prefix.
+const transform_in = `type Status int
+const (
+	StatusOK Status = iota
+	StatusNotFound
+	StatusHTTPVersionNotSupported
+	StatusTeapot // I'm a teapot
+)
+`
+
+const transform_out = `
+var _Status_names = []string{"ok", "not_found", "http_version_not_supported", "I'm a teapot"}
+
+const _Status_name = "oknot_foundhttp_version_not_supportedI'm a teapot"
+
+var _Status_index = [...]uint8{0, 2, 11, 37, 49}
+
+func (i Status) String() string {
+	if i < 0 || i >= Status(len(_Status_index)-1) {
+		return "Status(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Status_name[_Status_index[i]:_Status_index[i+1]]
+}
+
+func (i Status) Valid() bool {
+	return !(i < 0 || i >= Status(len(_Status_index)-1))
+}
+
+func (i Status) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Status(len(_Status_index)-1) {
+		return nil, &InvalidValueError{Type: "Status", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Status_name[_Status_index[i]:_Status_index[i+1]]), nil
+}
+
+func (i *Status) Set(s string) (err error) {
+	switch s {
+	case _Status_name[0:2]:
+		*i = StatusOK
+	case _Status_name[2:11]:
+		*i = StatusNotFound
+	case _Status_name[11:37]:
+		*i = StatusHTTPVersionNotSupported
+	case _Status_name[37:49]:
+		*i = StatusTeapot
+	default:
+		err = &ParseError{Type: "Status", Input: s, names: _Status_names}
+	}
+	return err
+}
+
+func (i *Status) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Status_name[0:2]:
+		*i = StatusOK
+	case _Status_name[2:11]:
+		*i = StatusNotFound
+	case _Status_name[11:37]:
+		*i = StatusHTTPVersionNotSupported
+	case _Status_name[37:49]:
+		*i = StatusTeapot
+	default:
+		err = &ParseError{Type: "Status", Input: string(s), names: _Status_names}
+	}
+	return err
+}
+`
+
+// Names of the text and SQL formats given by tag-style line comments.
+const tags_in = `type Status int
+const (
+	OK       Status = 0 // json:"ok" sql:"OK" display:"All good"
+	NotFound Status = 1 // json:"not_found"
+	Teapot   Status = 2 // display:"I'm a teapot"
+	Fine            = OK // json:"fine"
+)
+`
+
+const tags_out = `
+var _Status_names = []string{"All good", "NotFound", "I'm a teapot"}
+
+var _Status_text_names = []string{"ok", "not_found", "Teapot"}
+
+var _Status_sql_names = []string{"OK", "NotFound", "Teapot"}
+
+const _Status_name = "All goodNotFoundI'm a teapot"
+
+var _Status_index = [...]uint8{0, 8, 16, 28}
+
+func (i Status) String() string {
+	if i < 0 || i >= Status(len(_Status_index)-1) {
+		return "Status(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Status_name[_Status_index[i]:_Status_index[i+1]]
+}
+
+func (i Status) Valid() bool {
+	return !(i < 0 || i >= Status(len(_Status_index)-1))
+}
+
+func (i *Status) Set(s string) (err error) {
+	switch s {
+	case _Status_name[0:8]:
+		*i = OK
+	case _Status_name[8:16]:
+		*i = NotFound
+	case _Status_name[16:28]:
+		*i = Teapot
+	case "Fine":
+		*i = OK
+	default:
+		err = &ParseError{Type: "Status", Input: s, names: _Status_names}
+	}
+	return err
+}
+
+func (i Status) MarshalText() ([]byte, error) {
+	switch i {
+	case OK:
+		return []byte("ok"), nil
+	case NotFound:
+		return []byte("not_found"), nil
+	case Teapot:
+		return []byte("Teapot"), nil
+	}
+	return nil, &InvalidValueError{Type: "Status", Value: strconv.FormatInt(int64(i), 10)}
+}
+
+func _Status_text(s string) (Status, bool) {
+	switch s {
+	case "ok":
+		return OK, true
+	case "fine":
+		return OK, true
+	case "not_found":
+		return NotFound, true
+	case "Teapot":
+		return Teapot, true
+	}
+	return 0, false
+}
+
+func (i *Status) UnmarshalText(s []byte) error {
+	if v, ok := _Status_text(string(s)); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Status", Input: string(s), names: _Status_text_names}
+}
+
+func (i Status) Value() (driver.Value, error) {
+	switch i {
+	case OK:
+		return "OK", nil
+	case NotFound:
+		return "NotFound", nil
+	case Teapot:
+		return "Teapot", nil
+	}
+	return nil, &InvalidValueError{Type: "Status", Value: strconv.FormatInt(int64(i), 10)}
+}
+
+func _Status_sql(s string) (Status, bool) {
+	switch s {
+	case "OK":
+		return OK, true
+	case "Fine":
+		return OK, true
+	case "NotFound":
+		return NotFound, true
+	case "Teapot":
+		return Teapot, true
+	}
+	return 0, false
+}
+
+func (i *Status) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into Status", src)
+	}
+	if v, ok := _Status_sql(s); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Status", Input: s, names: _Status_sql_names}
+}
+`
+
+// Additional names given by the enum:alias directive.
+const aliasdir_in = `type Day int
+const (
+	Monday Day = iota //enum:alias=mon,lundi
+	Tuesday           // enum:alias=tue
+	//enum:alias=wed
+	Wednesday
+)
+`
+
+const aliasdir_out = `
+var _Day_names = []string{"Monday", "Tuesday", "Wednesday"}
+
+const _Day_name = "MondayTuesdayWednesday"
+
+var _Day_index = [...]uint8{0, 6, 13, 22}
+
+func (i Day) String() string {
+	if i < 0 || i >= Day(len(_Day_index)-1) {
+		return "Day(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Day_name[_Day_index[i]:_Day_index[i+1]]
+}
+
+func (i Day) Valid() bool {
+	return !(i < 0 || i >= Day(len(_Day_index)-1))
+}
+
+func (i Day) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Day(len(_Day_index)-1) {
+		return nil, &InvalidValueError{Type: "Day", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Day_name[_Day_index[i]:_Day_index[i+1]]), nil
+}
+
+var _Day_lookup_map = map[string]Day{
+	_Day_name[0:6]:   Monday,
+	_Day_name[6:13]:  Tuesday,
+	_Day_name[13:22]: Wednesday,
+	"mon":            Monday,
+	"lundi":          Monday,
+	"tue":            Tuesday,
+	"wed":            Wednesday,
+}
+
+func (i *Day) Set(s string) error {
+	if v, ok := _Day_lookup_map[s]; ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Day", Input: s, names: _Day_names}
+}
+
+func (i *Day) UnmarshalText(s []byte) error {
+	if v, ok := _Day_lookup_map[string(s)]; ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Day", Input: string(s), names: _Day_names}
+}
+`
+
+// Sentinel constants excluded by a pattern and by the enum:skip directive.
+const exclude_in = `type Level int
+const (
+	Debug Level = iota
+	Info
+	Warn
+	numLevels
+	//enum:skip
+	maxLevel = Warn
+)
+`
+
+const exclude_out = `
+var _Level_names = []string{"Debug", "Info", "Warn"}
+
+const _Level_name = "DebugInfoWarn"
+
+var _Level_index = [...]uint8{0, 5, 9, 13}
+
+func (i Level) String() string {
+	if i < 0 || i >= Level(len(_Level_index)-1) {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[i]:_Level_index[i+1]]
+}
+
+func (i Level) Valid() bool {
+	return !(i < 0 || i >= Level(len(_Level_index)-1))
+}
+
+func (i Level) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Level(len(_Level_index)-1) {
+		return nil, &InvalidValueError{Type: "Level", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Level_name[_Level_index[i]:_Level_index[i+1]]), nil
+}
+
+func (i *Level) Set(s string) (err error) {
+	switch s {
+	case _Level_name[0:5]:
+		*i = Debug
+	case _Level_name[5:9]:
+		*i = Info
+	case _Level_name[9:13]:
+		*i = Warn
+	default:
+		err = &ParseError{Type: "Level", Input: s, names: _Level_names}
+	}
+	return err
+}
+
+func (i *Level) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Level_name[0:5]:
+		*i = Debug
+	case _Level_name[5:9]:
+		*i = Info
+	case _Level_name[9:13]:
+		*i = Warn
+	default:
+		err = &ParseError{Type: "Level", Input: string(s), names: _Level_names}
+	}
+	return err
+}
+`
+
+// Deprecated constants of sparse values, with a replacement and an alias.
+const deprecated_in = `type Code int
+const (
+	// Deprecated: Use Moved instead.
+	Redirect Code = 301
+	Moved    Code = 301
+	// Deprecated: Use NotFound instead.
+	Missing  Code = 400
+	NotFound Code = 404
+	// Deprecated: No longer returned.
+	Gone     Code = 410
+	Teapot   Code = 418
+)
+`
+
+const deprecated_out = `
+var _Code_names = []string{"Moved", "NotFound", "Teapot"}
+
+const (
+	_Code_name_0 = "Moved"
+	_Code_name_1 = "Missing"
+	_Code_name_2 = "NotFound"
+	_Code_name_3 = "Gone"
+	_Code_name_4 = "Teapot"
+)
+
+func (i Code) String() string {
+	switch {
+	case i == 301:
+		return _Code_name_0
+	case i == 400:
+		return _Code_name_1
+	case i == 404:
+		return _Code_name_2
+	case i == 410:
+		return _Code_name_3
+	case i == 418:
+		return _Code_name_4
+	default:
+		return "Code(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+
+func (i Code) Valid() bool {
+	switch {
+	case i == 301:
+	case i == 400:
+	case i == 404:
+	case i == 410:
+	case i == 418:
+	default:
+		return false
+	}
+	return true
+}
+
+func (i *Code) Set(s string) (err error) {
+	switch s {
+	case _Code_name_0:
+		*i = Moved
+	case _Code_name_1:
+		*i = Missing
+	case _Code_name_2:
+		*i = NotFound
+	case _Code_name_3:
+		*i = Gone
+	case _Code_name_4:
+		*i = Teapot
+	case "Redirect":
+		*i = Moved
+	default:
+		err = &ParseError{Type: "Code", Input: s, names: _Code_names}
+	}
+	return err
+}
+
+func (i Code) MarshalText() ([]byte, error) {
+	switch i {
+	case Moved:
+		return []byte("Moved"), nil
+	case Missing:
+		return []byte("NotFound"), nil
+	case NotFound:
+		return []byte("NotFound"), nil
+	case Gone:
+		return []byte("Gone"), nil
+	case Teapot:
+		return []byte("Teapot"), nil
+	}
+	return nil, &InvalidValueError{Type: "Code", Value: strconv.FormatInt(int64(i), 10)}
+}
+
+func _Code_text(s string) (Code, bool) {
+	switch s {
+	case "Moved":
+		return Moved, true
+	case "Redirect":
+		return Moved, true
+	case "Missing":
+		return Missing, true
+	case "NotFound":
+		return NotFound, true
+	case "Gone":
+		return Gone, true
+	case "Teapot":
+		return Teapot, true
+	}
+	return 0, false
+}
+
+func (i *Code) UnmarshalText(s []byte) error {
+	if v, ok := _Code_text(string(s)); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Code", Input: string(s), names: _Code_names}
+}
+
+var _Code_values = [...]Code{Moved, NotFound, Teapot}
+
+const CodeCount = 3
+
+func CodeValues() []Code {
+	values := _Code_values
+	return values[:]
+}
+
+func CodeNames() []string {
+	names := make([]string, len(_Code_values))
+	for i, v := range _Code_values {
+		names[i] = v.String()
+	}
+	return names
+}
+
+func CodeFromIndex(n int) (Code, bool) {
+	if n < 0 || n >= len(_Code_values) {
+		return 0, false
+	}
+	return _Code_values[n], true
+}
+
+func (i Code) Index() int {
+	lo, hi := 0, len(_Code_values)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if _Code_values[m] < i {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	if lo < len(_Code_values) && _Code_values[lo] == i {
+		return lo
+	}
+	return -1
+}
+`
+
+// Unknown names unmarshaled as the constant marked with enum:default.
+const fallback_in = `type Method int
+const (
+	Other Method = iota //enum:default
+	Get
+	Post
+)
+`
+
+const fallback_out = `
+var _Method_names = []string{"Other", "Get", "Post"}
+
+const _Method_name = "OtherGetPost"
+
+var _Method_index = [...]uint8{0, 5, 8, 12}
+
+func (i Method) String() string {
+	if i < 0 || i >= Method(len(_Method_index)-1) {
+		return "Method(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Method_name[_Method_index[i]:_Method_index[i+1]]
+}
+
+func (i Method) Valid() bool {
+	return !(i < 0 || i >= Method(len(_Method_index)-1))
+}
+
+func (i Method) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Method(len(_Method_index)-1) {
+		return nil, &InvalidValueError{Type: "Method", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Method_name[_Method_index[i]:_Method_index[i+1]]), nil
+}
+
+func (i Method) Value() (driver.Value, error) {
+	if i < 0 || i >= Method(len(_Method_index)-1) {
+		return nil, &InvalidValueError{Type: "Method", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return _Method_name[_Method_index[i]:_Method_index[i+1]], nil
+}
+
+func (i *Method) Set(s string) (err error) {
+	switch s {
+	case _Method_name[0:5]:
+		*i = Other
+	case _Method_name[5:8]:
+		*i = Get
+	case _Method_name[8:12]:
+		*i = Post
+	default:
+		err = &ParseError{Type: "Method", Input: s, names: _Method_names}
+	}
+	return err
+}
+
+func (i *Method) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Method_name[0:5]:
+		*i = Other
+	case _Method_name[5:8]:
+		*i = Get
+	case _Method_name[8:12]:
+		*i = Post
+	default:
+		*i = Other
+	}
+	return err
+}
+
+func (i *Method) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case string:
+		if i.Set(s) != nil {
+			// Unknown names are scanned as the default value.
+			*i = Other
+		}
+		return nil
+	case []byte:
+		return i.UnmarshalText(s)
+	default:
+		return fmt.Errorf("cannot scan type %T into Method", src)
+	}
+}
+`
+
+// Invalid values marshaled and parsed in the form T(n), and bare integers.
+const lenient_in = `type Size uint8
+const (
+	Small Size = iota + 1
+	Medium
+	Large
+)
+`
+
+const lenient_out = `
+var _Size_names = []string{"Small", "Medium", "Large"}
+
+const _Size_name = "SmallMediumLarge"
+
+var _Size_index = [...]uint8{0, 5, 11, 16}
+
+func (i Size) String() string {
+	i -= 1
+	if i >= Size(len(_Size_index)-1) {
+		return "Size(" + strconv.FormatInt(int64(i+1), 10) + ")"
+	}
+	return _Size_name[_Size_index[i]:_Size_index[i+1]]
+}
+
+func (i Size) Valid() bool {
+	i -= 1
+	return !(i >= Size(len(_Size_index)-1))
+}
+
+func (i Size) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// _Size_number parses the form returned by String for invalid values,
+// "Size(n)", and bare integers.
+func _Size_number(s string) (Size, bool) {
+	const prefix = "Size("
+	if len(s) > len(prefix) && s[:len(prefix)] == prefix && s[len(s)-1] == ')' {
+		s = s[len(prefix) : len(s)-1]
+		// String formats the values above math.MaxInt64 as negative.
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil && n < 0 {
+			return Size(n), int64(Size(n)) == n
+		}
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || uint64(Size(n)) != n {
+		return 0, false
+	}
+	return Size(n), true
+}
+
+func (i *Size) Set(s string) (err error) {
+	switch s {
+	case _Size_name[0:5]:
+		*i = Small
+	case _Size_name[5:11]:
+		*i = Medium
+	case _Size_name[11:16]:
+		*i = Large
+	default:
+		if v, ok := _Size_fold(s); ok {
+			*i = v
+			return nil
+		}
+		if v, ok := _Size_number(s); ok {
+			*i = v
+			return nil
+		}
+		err = &ParseError{Type: "Size", Input: s, names: _Size_names}
+	}
+	return err
+}
+
+func (i *Size) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Size_name[0:5]:
+		*i = Small
+	case _Size_name[5:11]:
+		*i = Medium
+	case _Size_name[11:16]:
+		*i = Large
+	default:
+		if v, ok := _Size_fold(string(s)); ok {
+			*i = v
+			return nil
+		}
+		if v, ok := _Size_number(string(s)); ok {
+			*i = v
+			return nil
+		}
+		err = &ParseError{Type: "Size", Input: string(s), names: _Size_names}
+	}
+	return err
+}
+
+func _Size_fold(s string) (Size, bool) {
+	switch {
+	case strings.EqualFold(s, _Size_name[0:5]):
+		return Small, true
+	case strings.EqualFold(s, _Size_name[5:11]):
+		return Medium, true
+	case strings.EqualFold(s, _Size_name[11:16]):
+		return Large, true
+	}
+	return 0, false
+}
+`
+
+// Values marshaled as numbers.
+const encoding_in = `type Level int8
+const (
+	Low Level = -1
+	Mid Level = 0
+	High Level = 1
+)
+`
+
+const encoding_out = `
+var _Level_names = []string{"Low", "Mid", "High"}
+
+const _Level_name = "LowMidHigh"
+
+var _Level_index = [...]uint8{0, 3, 6, 10}
+
+func (i Level) String() string {
+	i -= -1
+	if i < 0 || i >= Level(len(_Level_index)-1) {
+		return "Level(" + strconv.FormatInt(int64(i+-1), 10) + ")"
+	}
+	return _Level_name[_Level_index[i]:_Level_index[i+1]]
+}
+
+func (i Level) Valid() bool {
+	i -= -1
+	return !(i < 0 || i >= Level(len(_Level_index)-1))
+}
+
+// _Level_number parses bare integers.
+func _Level_number(s string) (Level, bool) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || int64(Level(n)) != n {
+		return 0, false
+	}
+	return Level(n), true
+}
+
+func (i *Level) Set(s string) (err error) {
+	switch s {
+	case _Level_name[0:3]:
+		*i = Low
+	case _Level_name[3:6]:
+		*i = Mid
+	case _Level_name[6:10]:
+		*i = High
+	default:
+		err = &ParseError{Type: "Level", Input: s, names: _Level_names}
+	}
+	return err
+}
+
+func (i Level) MarshalText() ([]byte, error) {
+	if !i.Valid() {
+		return nil, &InvalidValueError{Type: "Level", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return strconv.AppendInt(nil, int64(i), 10), nil
+}
+
+func (i *Level) UnmarshalText(s []byte) error {
+	if v, ok := _Level_number(string(s)); ok && v.Valid() {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Level", Input: string(s)}
+}
+
+func (i Level) MarshalJSON() ([]byte, error) {
+	return i.MarshalText()
+}
+
+func (i *Level) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) != 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = []byte(s)
+	}
+	return i.UnmarshalText(data)
+}
+`
+
+// Direct MarshalJSON and UnmarshalJSON methods.
+const json_in = `type Suit int
+const (
+	Clubs Suit = iota
+	Diamonds
+	Hearts
+	Spades
+)
+`
+
+const json_out = `
+var _Suit_names = []string{"Clubs", "Diamonds", "Hearts", "Spades"}
+
+const _Suit_name = "ClubsDiamondsHeartsSpades"
+
+var _Suit_index = [...]uint8{0, 5, 13, 19, 25}
+
+func (i Suit) String() string {
+	if i < 0 || i >= Suit(len(_Suit_index)-1) {
+		return "Suit(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Suit_name[_Suit_index[i]:_Suit_index[i+1]]
+}
+
+func (i Suit) Valid() bool {
+	return !(i < 0 || i >= Suit(len(_Suit_index)-1))
+}
+
+func (i Suit) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Suit(len(_Suit_index)-1) {
+		return nil, &InvalidValueError{Type: "Suit", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Suit_name[_Suit_index[i]:_Suit_index[i+1]]), nil
+}
+
+func (i *Suit) Set(s string) (err error) {
+	switch s {
+	case _Suit_name[0:5]:
+		*i = Clubs
+	case _Suit_name[5:13]:
+		*i = Diamonds
+	case _Suit_name[13:19]:
+		*i = Hearts
+	case _Suit_name[19:25]:
+		*i = Spades
+	default:
+		err = &ParseError{Type: "Suit", Input: s, names: _Suit_names}
+	}
+	return err
+}
+
+func (i *Suit) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Suit_name[0:5]:
+		*i = Clubs
+	case _Suit_name[5:13]:
+		*i = Diamonds
+	case _Suit_name[13:19]:
+		*i = Hearts
+	case _Suit_name[19:25]:
+		*i = Spades
+	default:
+		err = &ParseError{Type: "Suit", Input: string(s), names: _Suit_names}
+	}
+	return err
+}
+
+func (i Suit) MarshalJSON() ([]byte, error) {
+	if !i.Valid() {
+		_, err := i.MarshalText()
+		return nil, err
+	}
+	s := i.String()
+	b := make([]byte, len(s)+2)
+	b[0] = '"'
+	copy(b[1:], s)
+	b[len(b)-1] = '"'
+	return b, nil
+}
+
+func (i *Suit) UnmarshalJSON(data []byte) error {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		if string(data) == "null" {
+			return nil
+		}
+		return &ParseError{Type: "Suit", Input: string(data)}
+	}
+	s := data[1 : len(data)-1]
+	for _, c := range s {
+		if c == '\\' {
+			// Decode the escape sequences.
+			var u string
+			if err := json.Unmarshal(data, &u); err != nil {
+				return err
+			}
+			return i.UnmarshalText([]byte(u))
+		}
+	}
+	return i.UnmarshalText(s)
+}
+`
+
+// Binary marshalers of an unsigned type.
+const binary_in = `type Shape uint16
+const (
+	Circle Shape = iota + 1
+	Square
+	Triangle
+)
+`
+
+const binary_out = `
+var _Shape_names = []string{"Circle", "Square", "Triangle"}
+
+const _Shape_name = "CircleSquareTriangle"
+
+var _Shape_index = [...]uint8{0, 6, 12, 20}
+
+func (i Shape) String() string {
+	i -= 1
+	if i >= Shape(len(_Shape_index)-1) {
+		return "Shape(" + strconv.FormatInt(int64(i+1), 10) + ")"
+	}
+	return _Shape_name[_Shape_index[i]:_Shape_index[i+1]]
+}
+
+func (i Shape) Valid() bool {
+	i -= 1
+	return !(i >= Shape(len(_Shape_index)-1))
+}
+
+func (i Shape) MarshalText() ([]byte, error) {
+	i -= 1
+	if i >= Shape(len(_Shape_index)-1) {
+		return nil, &InvalidValueError{Type: "Shape", Value: strconv.FormatInt(int64(i+1), 10)}
+	}
+	return []byte(_Shape_name[_Shape_index[i]:_Shape_index[i+1]]), nil
+}
+
+func (i *Shape) Set(s string) (err error) {
+	switch s {
+	case _Shape_name[0:6]:
+		*i = Circle
+	case _Shape_name[6:12]:
+		*i = Square
+	case _Shape_name[12:20]:
+		*i = Triangle
+	default:
+		err = &ParseError{Type: "Shape", Input: s, names: _Shape_names}
+	}
+	return err
+}
+
+func (i *Shape) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Shape_name[0:6]:
+		*i = Circle
+	case _Shape_name[6:12]:
+		*i = Square
+	case _Shape_name[12:20]:
+		*i = Triangle
+	default:
+		err = &ParseError{Type: "Shape", Input: string(s), names: _Shape_names}
+	}
+	return err
+}
+
+func (i Shape) AppendBinary(b []byte) ([]byte, error) {
+	if !i.Valid() {
+		return nil, &InvalidValueError{Type: "Shape", Value: strconv.FormatUint(uint64(i), 10)}
+	}
+	return binary.AppendUvarint(b, uint64(i)), nil
+}
+
+func (i Shape) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(nil)
+}
+
+func (i *Shape) UnmarshalBinary(data []byte) error {
+	x, n := binary.Uvarint(data)
+	if n <= 0 || n != len(data) {
+		return &ParseError{Type: "Shape", Input: hex.EncodeToString(data)}
+	}
+	v := Shape(x)
+	if uint64(v) != x || !v.Valid() {
+		return &InvalidValueError{Type: "Shape", Value: strconv.FormatUint(x, 10)}
+	}
+	*i = v
+	return nil
+}
+`
+
+// YAML marshalers of a type encoded as numbers.
+const yaml_in = `type Priority uint
+const (
+	Low Priority = iota
+	Normal
+	High
+)
+`
+
+const yaml_out = `
+var _Priority_names = []string{"Low", "Normal", "High"}
+
+const _Priority_name = "LowNormalHigh"
+
+var _Priority_index = [...]uint8{0, 3, 9, 13}
+
+func (i Priority) String() string {
+	if i >= Priority(len(_Priority_index)-1) {
+		return "Priority(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Priority_name[_Priority_index[i]:_Priority_index[i+1]]
+}
+
+func (i Priority) Valid() bool {
+	return !(i >= Priority(len(_Priority_index)-1))
+}
+
+// _Priority_number parses bare integers.
+func _Priority_number(s string) (Priority, bool) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || uint64(Priority(n)) != n {
+		return 0, false
+	}
+	return Priority(n), true
+}
+
+func (i *Priority) Set(s string) (err error) {
+	switch s {
+	case _Priority_name[0:3]:
+		*i = Low
+	case _Priority_name[3:9]:
+		*i = Normal
+	case _Priority_name[9:13]:
+		*i = High
+	default:
+		err = &ParseError{Type: "Priority", Input: s, names: _Priority_names}
+	}
+	return err
+}
+
+func (i Priority) MarshalText() ([]byte, error) {
+	if !i.Valid() {
+		return nil, &InvalidValueError{Type: "Priority", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return strconv.AppendUint(nil, uint64(i), 10), nil
+}
+
+func (i *Priority) UnmarshalText(s []byte) error {
+	if v, ok := _Priority_number(string(s)); ok && v.Valid() {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Priority", Input: string(s)}
+}
+
+func (i Priority) MarshalJSON() ([]byte, error) {
+	return i.MarshalText()
+}
+
+func (i *Priority) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) != 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = []byte(s)
+	}
+	return i.UnmarshalText(data)
+}
+
+func (i Priority) MarshalYAML() (interface{}, error) {
+	_, err := i.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return uint64(i), nil
+}
+
+func (i *Priority) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return i.UnmarshalText([]byte(s))
+}
+`
+
+// XML attribute and element marshalers.
+const xml_in = `type Align int
+const (
+	Left Align = iota
+	Center
+	Right
+)
+`
+
+const xml_out = `
+var _Align_names = []string{"Left", "Center", "Right"}
+
+const _Align_name = "LeftCenterRight"
+
+var _Align_index = [...]uint8{0, 4, 10, 15}
+
+func (i Align) String() string {
+	if i < 0 || i >= Align(len(_Align_index)-1) {
+		return "Align(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Align_name[_Align_index[i]:_Align_index[i+1]]
+}
+
+func (i Align) Valid() bool {
+	return !(i < 0 || i >= Align(len(_Align_index)-1))
+}
+
+func (i Align) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Align(len(_Align_index)-1) {
+		return nil, &InvalidValueError{Type: "Align", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Align_name[_Align_index[i]:_Align_index[i+1]]), nil
+}
+
+func (i *Align) Set(s string) (err error) {
+	switch s {
+	case _Align_name[0:4]:
+		*i = Left
+	case _Align_name[4:10]:
+		*i = Center
+	case _Align_name[10:15]:
+		*i = Right
+	default:
+		err = &ParseError{Type: "Align", Input: s, names: _Align_names}
+	}
+	return err
+}
+
+func (i *Align) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Align_name[0:4]:
+		*i = Left
+	case _Align_name[4:10]:
+		*i = Center
+	case _Align_name[10:15]:
+		*i = Right
+	default:
+		err = &ParseError{Type: "Align", Input: string(s), names: _Align_names}
+	}
+	return err
+}
+
+func (i Align) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	text, err := i.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+func (i *Align) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.UnmarshalText([]byte(attr.Value))
+}
+
+func (i Align) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	text, err := i.MarshalText()
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(string(text), start)
+}
+
+func (i *Align) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return i.UnmarshalText([]byte(s))
+}
+`
+
+// LogValue method returning a group of the name and value.
+const slog_in = `type Severity int8
+const (
+	Minor Severity = iota - 1
+	Major
+	Critical
+)
+`
+
+const slog_out = `
+var _Severity_names = []string{"Minor", "Major", "Critical"}
+
+const _Severity_name = "MinorMajorCritical"
+
+var _Severity_index = [...]uint8{0, 5, 10, 18}
+
+func (i Severity) String() string {
+	i -= -1
+	if i < 0 || i >= Severity(len(_Severity_index)-1) {
+		return "Severity(" + strconv.FormatInt(int64(i+-1), 10) + ")"
+	}
+	return _Severity_name[_Severity_index[i]:_Severity_index[i+1]]
+}
+
+func (i Severity) Valid() bool {
+	i -= -1
+	return !(i < 0 || i >= Severity(len(_Severity_index)-1))
+}
+
+func (i Severity) MarshalText() ([]byte, error) {
+	i -= -1
+	if i < 0 || i >= Severity(len(_Severity_index)-1) {
+		return nil, &InvalidValueError{Type: "Severity", Value: strconv.FormatInt(int64(i+-1), 10)}
+	}
+	return []byte(_Severity_name[_Severity_index[i]:_Severity_index[i+1]]), nil
+}
+
+func (i *Severity) Set(s string) (err error) {
+	switch s {
+	case _Severity_name[0:5]:
+		*i = Minor
+	case _Severity_name[5:10]:
+		*i = Major
+	case _Severity_name[10:18]:
+		*i = Critical
+	default:
+		err = &ParseError{Type: "Severity", Input: s, names: _Severity_names}
+	}
+	return err
+}
+
+func (i *Severity) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Severity_name[0:5]:
+		*i = Minor
+	case _Severity_name[5:10]:
+		*i = Major
+	case _Severity_name[10:18]:
+		*i = Critical
+	default:
+		err = &ParseError{Type: "Severity", Input: string(s), names: _Severity_names}
+	}
+	return err
+}
+
+func (i Severity) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("name", i.String()), slog.Int64("value", int64(i)))
+}
+`
+
+// Format and GoString methods.
+const format_in = `type Planet uint8
+const (
+	Mercury Planet = iota + 1
+	Venus
+	Earth
+)
+`
+
+const format_out = `
+var _Planet_names = []string{"Mercury", "Venus", "Earth"}
+
+const _Planet_name = "MercuryVenusEarth"
+
+var _Planet_index = [...]uint8{0, 7, 12, 17}
+
+func (i Planet) String() string {
+	i -= 1
+	if i >= Planet(len(_Planet_index)-1) {
+		return "Planet(" + strconv.FormatInt(int64(i+1), 10) + ")"
+	}
+	return _Planet_name[_Planet_index[i]:_Planet_index[i+1]]
+}
+
+func (i Planet) Valid() bool {
+	i -= 1
+	return !(i >= Planet(len(_Planet_index)-1))
+}
+
+func (i Planet) MarshalText() ([]byte, error) {
+	i -= 1
+	if i >= Planet(len(_Planet_index)-1) {
+		return nil, &InvalidValueError{Type: "Planet", Value: strconv.FormatInt(int64(i+1), 10)}
+	}
+	return []byte(_Planet_name[_Planet_index[i]:_Planet_index[i+1]]), nil
+}
+
+func (i *Planet) Set(s string) (err error) {
+	switch s {
+	case _Planet_name[0:7]:
+		*i = Mercury
+	case _Planet_name[7:12]:
+		*i = Venus
+	case _Planet_name[12:17]:
+		*i = Earth
+	default:
+		err = &ParseError{Type: "Planet", Input: s, names: _Planet_names}
+	}
+	return err
+}
+
+func (i *Planet) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Planet_name[0:7]:
+		*i = Mercury
+	case _Planet_name[7:12]:
+		*i = Venus
+	case _Planet_name[12:17]:
+		*i = Earth
+	default:
+		err = &ParseError{Type: "Planet", Input: string(s), names: _Planet_names}
+	}
+	return err
+}
+
+func (i Planet) Format(f fmt.State, verb rune) {
+	var v interface{}
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			v = i.GoString()
+		case f.Flag('+') && i.Valid():
+			v = "Planet(" + i.String() + "=" + strconv.FormatUint(uint64(i), 10) + ")"
+		default:
+			v = i.String()
+		}
+		verb = 's'
+	case 's', 'q':
+		v = i.String()
+	default:
+		v = uint64(i)
+	}
+	format := "%"
+	for _, c := range "+-# 0" {
+		if f.Flag(int(c)) {
+			format += string(c)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		format += strconv.Itoa(w)
+	}
+	if p, ok := f.Precision(); ok {
+		format += "." + strconv.Itoa(p)
+	}
+	fmt.Fprintf(f, format+string(verb), v)
+}
+
+func (i Planet) GoString() string {
+	switch i {
+	case Mercury:
+		return "test.Mercury"
+	case Venus:
+		return "test.Venus"
+	case Earth:
+		return "test.Earth"
+	}
+	return "test.Planet(" + strconv.FormatUint(uint64(i), 10) + ")"
+}
+`
+
+// GraphQL enums.
+const graphql_in = `type Episode int
+const (
+	NewHope Episode = iota + 4
+	Empire
+	ReturnOfTheJedi
+)
+`
+
+const graphql_out = `
+var _Episode_names = []string{"NewHope", "Empire", "ReturnOfTheJedi"}
+
+var _Episode_graphql_names = []string{"NEW_HOPE", "EMPIRE", "RETURN_OF_THE_JEDI"}
+
+const _Episode_name = "NewHopeEmpireReturnOfTheJedi"
+
+var _Episode_index = [...]uint8{0, 7, 13, 28}
+
+func (i Episode) String() string {
+	i -= 4
+	if i < 0 || i >= Episode(len(_Episode_index)-1) {
+		return "Episode(" + strconv.FormatInt(int64(i+4), 10) + ")"
+	}
+	return _Episode_name[_Episode_index[i]:_Episode_index[i+1]]
+}
+
+func (i Episode) Valid() bool {
+	i -= 4
+	return !(i < 0 || i >= Episode(len(_Episode_index)-1))
+}
+
+func (i Episode) MarshalText() ([]byte, error) {
+	i -= 4
+	if i < 0 || i >= Episode(len(_Episode_index)-1) {
+		return nil, &InvalidValueError{Type: "Episode", Value: strconv.FormatInt(int64(i+4), 10)}
+	}
+	return []byte(_Episode_name[_Episode_index[i]:_Episode_index[i+1]]), nil
+}
+
+func (i *Episode) Set(s string) (err error) {
+	switch s {
+	case _Episode_name[0:7]:
+		*i = NewHope
+	case _Episode_name[7:13]:
+		*i = Empire
+	case _Episode_name[13:28]:
+		*i = ReturnOfTheJedi
+	default:
+		err = &ParseError{Type: "Episode", Input: s, names: _Episode_names}
+	}
+	return err
+}
+
+func (i *Episode) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Episode_name[0:7]:
+		*i = NewHope
+	case _Episode_name[7:13]:
+		*i = Empire
+	case _Episode_name[13:28]:
+		*i = ReturnOfTheJedi
+	default:
+		err = &ParseError{Type: "Episode", Input: string(s), names: _Episode_names}
+	}
+	return err
+}
+
+func (i Episode) MarshalGQL(w io.Writer) {
+	var s string
+	switch i {
+	case NewHope:
+		s = "NEW_HOPE"
+	case Empire:
+		s = "EMPIRE"
+	case ReturnOfTheJedi:
+		s = "RETURN_OF_THE_JEDI"
+	default:
+		s = i.String()
+	}
+	io.WriteString(w, strconv.Quote(s))
+}
+
+func _Episode_graphql(s string) (Episode, bool) {
+	switch s {
+	case "NEW_HOPE":
+		return NewHope, true
+	case "EMPIRE":
+		return Empire, true
+	case "RETURN_OF_THE_JEDI":
+		return ReturnOfTheJedi, true
+	}
+	return 0, false
+}
+
+func (i *Episode) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal type %T into Episode", v)
+	}
+	if v, ok := _Episode_graphql(s); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Episode", Input: s, names: _Episode_graphql_names}
+}
+`
+
+const tokens_in = `type Token int
+const (
+	And Token = iota // &
+	Or               // |
+	Add              // +
+	Sub              // -
+	Ident
+	Period // .
+
+	// not to be used
+	SingleBefore
+	// not to be used
+	BeforeAndInline // inline
+	InlineGeneral /* inline general */
+)
+`
+
+const tokens_out = `
+var _Token_names = []string{"&", "|", "+", "-", "Ident", ".", "SingleBefore", "inline", "inline general"}
+
+const _Token_name = "&|+-Ident.SingleBeforeinlineinline general"
+
+var _Token_index = [...]uint8{0, 1, 2, 3, 4, 9, 10, 22, 28, 42}
+
+func (i Token) String() string {
+	if i < 0 || i >= Token(len(_Token_index)-1) {
+		return "Token(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Token_name[_Token_index[i]:_Token_index[i+1]]
+}
+
+func (i Token) Valid() bool {
+	return !(i < 0 || i >= Token(len(_Token_index)-1))
+}
+
+func (i Token) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Token(len(_Token_index)-1) {
+		return nil, &InvalidValueError{Type: "Token", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Token_name[_Token_index[i]:_Token_index[i+1]]), nil
+}
+
+func (i *Token) Set(s string) (err error) {
+	switch s {
+	case _Token_name[0:1]:
+		*i = And
+	case _Token_name[1:2]:
+		*i = Or
+	case _Token_name[2:3]:
+		*i = Add
+	case _Token_name[3:4]:
+		*i = Sub
+	case _Token_name[4:9]:
+		*i = Ident
+	case _Token_name[9:10]:
+		*i = Period
+	case _Token_name[10:22]:
+		*i = SingleBefore
+	case _Token_name[22:28]:
+		*i = BeforeAndInline
+	case _Token_name[28:42]:
+		*i = InlineGeneral
+	default:
+		err = &ParseError{Type: "Token", Input: s, names: _Token_names}
+	}
+	return err
+}
+
+func (i *Token) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Token_name[0:1]:
+		*i = And
+	case _Token_name[1:2]:
+		*i = Or
+	case _Token_name[2:3]:
+		*i = Add
+	case _Token_name[3:4]:
+		*i = Sub
+	case _Token_name[4:9]:
+		*i = Ident
+	case _Token_name[9:10]:
+		*i = Period
+	case _Token_name[10:22]:
+		*i = SingleBefore
+	case _Token_name[22:28]:
+		*i = BeforeAndInline
+	case _Token_name[28:42]:
+		*i = InlineGeneral
+	default:
+		err = &ParseError{Type: "Token", Input: string(s), names: _Token_names}
+	}
+	return err
+}
+`
+
+// Bit flags, including a zero value.
+const perm_in = `type Perm uint8
+const (
+	None Perm = 0
+	Read Perm = 1 << (iota - 1)
+	Write
+	Exec
+)
+`
+
+const perm_out = `
+var _Perm_names = []string{"None", "Read", "Write", "Exec"}
+
+const _Perm_name = "ReadWriteExec"
+
+var _Perm_index = [...]uint8{0, 4, 9, 13}
+
+var _Perm_values = [...]Perm{Read, Write, Exec}
+
+const _Perm_mask = Read | Write | Exec
+
+func (i Perm) String() string {
+	if i == 0 {
+		return "None"
+	}
+	var b []byte
+	for j := 0; j < len(_Perm_values); j++ {
+		v := _Perm_values[j]
+		if i&v == 0 {
+			continue
+		}
+		if i == v && len(b) == 0 {
+			return _Perm_name[_Perm_index[j]:_Perm_index[j+1]]
+		}
+		if len(b) != 0 {
+			b = append(b, '|')
+		}
+		b = append(b, _Perm_name[_Perm_index[j]:_Perm_index[j+1]]...)
+		i &^= v
+	}
+	if i != 0 {
+		if len(b) != 0 {
+			b = append(b, '|')
+		}
+		b = append(b, "0x"...)
+		b = strconv.AppendUint(b, uint64(i), 16)
+	}
+	return string(b)
+}
+
+func (i Perm) Valid() bool {
+	return i&^_Perm_mask == 0
+}
+
+func (i Perm) MarshalText() ([]byte, error) {
+	if i.Valid() {
+		return []byte(i.String()), nil
+	}
+	return nil, &InvalidValueError{Type: "Perm", Value: strconv.FormatInt(int64(i), 10)}
+}
+
+func (i Perm) Has(f Perm) bool {
+	return i&f == f
+}
+
+func (i Perm) SetFlag(f Perm) Perm {
+	return i | f
+}
+
+func (i Perm) ClearFlag(f Perm) Perm {
+	return i &^ f
+}
+
+func (i Perm) Flags() []Perm {
+	var flags []Perm
+	for _, v := range _Perm_values {
+		if i&v != 0 {
+			flags = append(flags, v)
+		}
+	}
+	return flags
+}
+
+func _Perm_parse(s string) (Perm, bool) {
+	if s == "None" {
+		return 0, true
+	}
+	var v Perm
+	for {
+		n := 0
+		for n < len(s) && s[n] != '|' {
+			n++
+		}
+		switch s[:n] {
+		case _Perm_name[0:4]:
+			v |= Read
+		case _Perm_name[4:9]:
+			v |= Write
+		case _Perm_name[9:13]:
+			v |= Exec
+		default:
+			return 0, false
+		}
+		if n == len(s) {
+			return v, true
+		}
+		s = s[n+1:]
+	}
+}
+
+func (i *Perm) Set(s string) error {
+	if v, ok := _Perm_parse(s); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Perm", Input: s, names: _Perm_names}
+}
+
+func (i *Perm) UnmarshalText(s []byte) error {
+	if v, ok := _Perm_parse(string(s)); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Perm", Input: string(s), names: _Perm_names}
+}
+`
+
+// Case-insensitive parsing.
+const nocase_in = `type Color int
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+`
+
+const nocase_out = `
+var _Color_names = []string{"Red", "Green", "Blue"}
+
+const _Color_name = "RedGreenBlue"
+
+var _Color_index = [...]uint8{0, 3, 8, 12}
+
+func (i Color) String() string {
+	if i < 0 || i >= Color(len(_Color_index)-1) {
+		return "Color(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Color_name[_Color_index[i]:_Color_index[i+1]]
+}
+
+func (i Color) Valid() bool {
+	return !(i < 0 || i >= Color(len(_Color_index)-1))
+}
+
+func (i Color) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Color(len(_Color_index)-1) {
+		return nil, &InvalidValueError{Type: "Color", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Color_name[_Color_index[i]:_Color_index[i+1]]), nil
+}
+
+func (i *Color) Set(s string) (err error) {
+	switch s {
+	case _Color_name[0:3]:
+		*i = Red
+	case _Color_name[3:8]:
+		*i = Green
+	case _Color_name[8:12]:
+		*i = Blue
+	default:
+		if v, ok := _Color_fold(s); ok {
+			*i = v
+			return nil
+		}
+		err = &ParseError{Type: "Color", Input: s, names: _Color_names}
+	}
+	return err
+}
+
+func (i *Color) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Color_name[0:3]:
+		*i = Red
+	case _Color_name[3:8]:
+		*i = Green
+	case _Color_name[8:12]:
+		*i = Blue
+	default:
+		if v, ok := _Color_fold(string(s)); ok {
+			*i = v
+			return nil
+		}
+		err = &ParseError{Type: "Color", Input: string(s), names: _Color_names}
+	}
+	return err
+}
+
+func _Color_fold(s string) (Color, bool) {
+	switch {
+	case strings.EqualFold(s, _Color_name[0:3]):
+		return Red, true
+	case strings.EqualFold(s, _Color_name[3:8]):
+		return Green, true
+	case strings.EqualFold(s, _Color_name[8:12]):
+		return Blue, true
+	}
+	return 0, false
+}
+`
+
+// Value listing functions.
+const values_in = `type Size uint
+const (
+	Small Size = 1
+	Medium Size = 2
+	Large Size = 4
+)
+`
+
+const values_out = `
+var _Size_names = []string{"Small", "Medium", "Large"}
+
+const (
+	_Size_name_0 = "SmallMedium"
+	_Size_name_1 = "Large"
+)
+
+var (
+	_Size_index_0 = [...]uint8{0, 5, 11}
+)
+
+func (i Size) String() string {
+	switch {
+	case 1 <= i && i <= 2:
+		i -= 1
+		return _Size_name_0[_Size_index_0[i]:_Size_index_0[i+1]]
+	case i == 4:
+		return _Size_name_1
+	default:
+		return "Size(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+
+func (i Size) Valid() bool {
+	switch {
+	case 1 <= i && i <= 2:
+	case i == 4:
+	default:
+		return false
+	}
+	return true
+}
+
+func (i Size) MarshalText() ([]byte, error) {
+	if i.Valid() {
+		return []byte(i.String()), nil
+	}
+	return nil, &InvalidValueError{Type: "Size", Value: strconv.FormatInt(int64(i), 10)}
+}
+
+func (i *Size) Set(s string) (err error) {
+	switch s {
+	case _Size_name_0[0:5]:
+		*i = Small
+	case _Size_name_0[5:11]:
+		*i = Medium
+	case _Size_name_1:
+		*i = Large
+	default:
+		err = &ParseError{Type: "Size", Input: s, names: _Size_names}
+	}
+	return err
+}
+
+func (i *Size) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Size_name_0[0:5]:
+		*i = Small
+	case _Size_name_0[5:11]:
+		*i = Medium
+	case _Size_name_1:
+		*i = Large
+	default:
+		err = &ParseError{Type: "Size", Input: string(s), names: _Size_names}
+	}
+	return err
+}
+
+var _Size_values = [...]Size{Small, Medium, Large}
+
+const SizeCount = 3
+
+func SizeValues() []Size {
+	values := _Size_values
+	return values[:]
+}
+
+func SizeNames() []string {
+	names := make([]string, len(_Size_values))
+	for i, v := range _Size_values {
+		names[i] = v.String()
+	}
+	return names
+}
+
+func SizeFromIndex(n int) (Size, bool) {
+	if n < 0 || n >= len(_Size_values) {
+		return 0, false
+	}
+	return _Size_values[n], true
+}
+
+func (i Size) Index() int {
+	lo, hi := 0, len(_Size_values)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if _Size_values[m] < i {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	if lo < len(_Size_values) && _Size_values[lo] == i {
+		return lo
+	}
+	return -1
+}
+`
+
+// String underlying type.
+const color_in = `type Color string
+const (
+	Red   Color = "red"
+	Green Color = "green"
+	Blue  Color = "blue"
+)
+`
+
+const color_out = `
+var _Color_names = []string{"red", "green", "blue"}
+
+func (i Color) String() string {
+	return string(i)
+}
+
+func (i Color) Valid() bool {
+	switch i {
+	case Blue, Green, Red:
+		return true
+	}
+	return false
+}
+
+func (i Color) MarshalText() ([]byte, error) {
+	if i.Valid() {
+		return []byte(i), nil
+	}
+	return nil, &InvalidValueError{Type: "Color", Value: strconv.Quote(string(i))}
+}
+
+func (i *Color) Set(s string) (err error) {
+	switch s {
+	case "blue":
+		*i = Blue
+	case "green":
+		*i = Green
+	case "red":
+		*i = Red
+	default:
+		err = &ParseError{Type: "Color", Input: s, names: _Color_names}
+	}
+	return err
+}
+
+func (i *Color) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case "blue":
+		*i = Blue
+	case "green":
+		*i = Green
+	case "red":
+		*i = Red
+	default:
+		err = &ParseError{Type: "Color", Input: string(s), names: _Color_names}
+	}
+	return err
+}
+`
+
+// Duplicate values with the primary name set by a directive.
+const primary_in = `type Pill int
+const (
+	Placebo Pill = iota
+	Aspirin
+	Ibuprofen
+	Paracetamol
+	//enum:primary
+	Acetaminophen = Paracetamol
+)
+`
+
+const primary_out = `
+var _Pill_names = []string{"Placebo", "Aspirin", "Ibuprofen", "Acetaminophen"}
+
+const _Pill_name = "PlaceboAspirinIbuprofenAcetaminophen"
+
+var _Pill_index = [...]uint8{0, 7, 14, 23, 36}
+
+func (i Pill) String() string {
+	if i < 0 || i >= Pill(len(_Pill_index)-1) {
+		return "Pill(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Pill_name[_Pill_index[i]:_Pill_index[i+1]]
+}
+
+func (i Pill) Valid() bool {
+	return !(i < 0 || i >= Pill(len(_Pill_index)-1))
+}
+
+func (i Pill) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Pill(len(_Pill_index)-1) {
+		return nil, &InvalidValueError{Type: "Pill", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Pill_name[_Pill_index[i]:_Pill_index[i+1]]), nil
+}
+
+func (i *Pill) Set(s string) (err error) {
+	switch s {
+	case _Pill_name[0:7]:
+		*i = Placebo
+	case _Pill_name[7:14]:
+		*i = Aspirin
+	case _Pill_name[14:23]:
+		*i = Ibuprofen
+	case _Pill_name[23:36]:
+		*i = Acetaminophen
+	case "Paracetamol":
+		*i = Acetaminophen
+	default:
+		err = &ParseError{Type: "Pill", Input: s, names: _Pill_names}
+	}
+	return err
+}
+
+func (i *Pill) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Pill_name[0:7]:
+		*i = Placebo
+	case _Pill_name[7:14]:
+		*i = Aspirin
+	case _Pill_name[14:23]:
+		*i = Ibuprofen
+	case _Pill_name[23:36]:
+		*i = Acetaminophen
+	case "Paracetamol":
+		*i = Acetaminophen
+	default:
+		err = &ParseError{Type: "Pill", Input: string(s), names: _Pill_names}
+	}
+	return err
+}
+`
+
+// An alias followed by a sentinel computed from it, which is not a value.
+const sentinel_in = `type Weekday int
+const (
+	Monday Weekday = iota
+	Tuesday
+	Wednesday
+	Last = Wednesday
+	numDays = Last + 1
+)
+`
+
+const sentinel_out = `
+var _Weekday_names = []string{"Monday", "Tuesday", "Wednesday"}
+
+const _Weekday_name = "MondayTuesdayWednesday"
+
+var _Weekday_index = [...]uint8{0, 6, 13, 22}
+
+func (i Weekday) String() string {
+	if i < 0 || i >= Weekday(len(_Weekday_index)-1) {
+		return "Weekday(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Weekday_name[_Weekday_index[i]:_Weekday_index[i+1]]
+}
+
+func (i Weekday) Valid() bool {
+	return !(i < 0 || i >= Weekday(len(_Weekday_index)-1))
+}
+
+func (i Weekday) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Weekday(len(_Weekday_index)-1) {
+		return nil, &InvalidValueError{Type: "Weekday", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Weekday_name[_Weekday_index[i]:_Weekday_index[i+1]]), nil
+}
+
+func (i *Weekday) Set(s string) (err error) {
+	switch s {
+	case _Weekday_name[0:6]:
+		*i = Monday
+	case _Weekday_name[6:13]:
+		*i = Tuesday
+	case _Weekday_name[13:22]:
+		*i = Wednesday
+	case "Last":
+		*i = Wednesday
+	default:
+		err = &ParseError{Type: "Weekday", Input: s, names: _Weekday_names}
+	}
+	return err
+}
+
+func (i *Weekday) UnmarshalText(s []byte) (err error) {
+	switch string(s) {
+	case _Weekday_name[0:6]:
+		*i = Monday
+	case _Weekday_name[6:13]:
+		*i = Tuesday
+	case _Weekday_name[13:22]:
+		*i = Wednesday
+	case "Last":
+		*i = Wednesday
+	default:
+		err = &ParseError{Type: "Weekday", Input: string(s), names: _Weekday_names}
+	}
+	return err
+}
+`
+
+// Perfect hash lookup.
+const phash_in = `type Size int
+const (
+	Small Size = iota
+	Medium
+	Large
+	ExtraLarge
+	Huge = ExtraLarge
+)
+`
+
+const phash_out = `
+var _Size_names = []string{"Small", "Medium", "Large", "ExtraLarge"}
+
+const _Size_name = "SmallMediumLargeExtraLarge"
+
+var _Size_index = [...]uint8{0, 5, 11, 16, 26}
+
+func (i Size) String() string {
+	if i < 0 || i >= Size(len(_Size_index)-1) {
+		return "Size(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Size_name[_Size_index[i]:_Size_index[i+1]]
+}
+
+func (i Size) Valid() bool {
+	return !(i < 0 || i >= Size(len(_Size_index)-1))
+}
+
+func (i Size) MarshalText() ([]byte, error) {
+	if i < 0 || i >= Size(len(_Size_index)-1) {
+		return nil, &InvalidValueError{Type: "Size", Value: strconv.FormatInt(int64(i), 10)}
+	}
+	return []byte(_Size_name[_Size_index[i]:_Size_index[i+1]]), nil
+}
+
+const _Size_phash_names = "LargeHugeExtraLargeMediumSmall"
+
+var (
+	_Size_phash_index  = [...]uint8{0, 5, 9, 19, 25, 30}
+	_Size_phash_values = [...]Size{Large, ExtraLarge, ExtraLarge, Medium, Small}
+	_Size_phash_disp   = [...]uint32{0, 0}
+	_Size_phash_slots  = [...]uint8{0, 1, 2, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 4, 5, 0}
+)
+
+func _Size_phash(s string) (Size, bool) {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	h ^= _Size_phash_disp[h&1]
+	k := int(_Size_phash_slots[(h*0x9e3779b1)>>28])
+	if k == 0 || s != _Size_phash_names[_Size_phash_index[k-1]:_Size_phash_index[k]] {
+		return 0, false
+	}
+	return _Size_phash_values[k-1], true
+}
+
+func (i *Size) Set(s string) error {
+	if v, ok := _Size_phash(s); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Size", Input: s, names: _Size_names}
+}
+
+func (i *Size) UnmarshalText(s []byte) error {
+	if v, ok := _Size_phash(string(s)); ok {
+		*i = v
+		return nil
+	}
+	return &ParseError{Type: "Size", Input: string(s), names: _Size_names}
+}
+`
+
+func TestGolden(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	dir, err := ioutil.TempDir("", "stringer")
+	if err != nil {
+		t.Error(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tmp, err := ioutil.TempDir("", "stringer-tests")
+	if err != nil {
+		t.Error(err)
+	}
+
+	var failed []string
+
+	for _, test := range golden {
+		g := generator{Options: test.opts}
+		input := "package test\n" + test.input
+		file := test.name + ".go"
+		absFile := filepath.Join(dir, file)
+		err := ioutil.WriteFile(absFile, []byte(input), 0644)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if err := g.parsePackage([]string{absFile}, nil); err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		// Extract the name and type of the constant from the first line.
+		tokens := strings.SplitN(test.input, " ", 3)
+		if len(tokens) != 3 {
+			t.Fatalf("%s: need type declaration on first line", test.name)
+		}
+		if err := g.generate(tokens[1]); err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		src, err := g.format()
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+		}
+		got := string(src)
+		// ignore trailing whitespace (it's not important)
+		got = strings.TrimRight(got, "\n")
+		test.output = strings.TrimRight(test.output, "\n")
+		if got != test.output {
+			if testing.Verbose() {
+				t.Errorf("%s: got(%d)\n====\n%s====\nexpected(%d)\n====\n%s====\n",
+					test.name, len(got), got, len(test.output), test.output)
+			} else {
+				t.Error(test.name)
+			}
+			failed = append(failed, test.name)
+
+			base := filepath.Join(tmp, test.name)
+			if err := ioutil.WriteFile(base+".got", []byte(got), 0644); err != nil {
+				t.Error(err)
+			}
+			if err := ioutil.WriteFile(base+".exp", []byte(test.output), 0644); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+
+	if t.Failed() {
+		t.Errorf("\nTest failures:\n  %s\nFailure diffs are saved at: %s",
+			strings.Join(failed, "\n  "), tmp)
+	} else {
+		os.RemoveAll(tmp)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains tests for some of the internal functions.
+
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"testing"
+)
+
+// Helpers to save typing in the test cases.
+type u []uint64
+type uu [][]uint64
+
+type SplitTest struct {
+	input  u
+	output uu
+	signed bool
+}
+
+var (
+	m2  = uint64(2)
+	m1  = uint64(1)
+	m0  = uint64(0)
+	m_1 = ^uint64(0)     // -1 when signed.
+	m_2 = ^uint64(0) - 1 // -2 when signed.
+)
+
+var splitTests = []SplitTest{
+	// No need for a test for the empty case; that's picked off before splitIntoRuns.
+	// Single value.
+	{u{1}, uu{u{1}}, false},
+	// Out of order.
+	{u{3, 2, 1}, uu{u{1, 2, 3}}, true},
+	// Out of order.
+	{u{3, 2, 1}, uu{u{1, 2, 3}}, false},
+	// A gap at the beginning.
+	{u{1, 33, 32, 31}, uu{u{1}, u{31, 32, 33}}, true},
+	// A gap in the middle, in mixed order.
+	{u{33, 7, 32, 31, 9, 8}, uu{u{7, 8, 9}, u{31, 32, 33}}, true},
+	// Gaps throughout
+	{u{33, 44, 1, 32, 45, 31}, uu{u{1}, u{31, 32, 33}, u{44, 45}}, true},
+	// Unsigned values spanning 0.
+	{u{m1, m0, m_1, m2, m_2}, uu{u{m0, m1, m2}, u{m_2, m_1}}, false},
+	// Signed values spanning 0
+	{u{m1, m0, m_1, m2, m_2}, uu{u{m_2, m_1, m0, m1, m2}}, true},
+}
+
+func TestSplitIntoRuns(t *testing.T) {
+Outer:
+	for n, test := range splitTests {
+		values := make([]value, len(test.input))
+		for i, v := range test.input {
+			values[i] = value{value: v, signed: test.signed, str: fmt.Sprint(v)}
+		}
+		runs := splitIntoRuns(values)
+		if len(runs) != len(test.output) {
+			t.Errorf("#%d: %v: got %d runs; expected %d", n, test.input, len(runs), len(test.output))
+			continue
+		}
+		for i, run := range runs {
+			if len(run) != len(test.output[i]) {
+				t.Errorf("#%d: got %v; expected %v", n, runs, test.output)
+				continue Outer
+			}
+			for j, v := range run {
+				if v.value != test.output[i][j] {
+					t.Errorf("#%d: got %v; expected %v", n, runs, test.output)
+					continue Outer
+				}
+			}
+		}
+	}
+}
+
+func TestPhash(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 8, 31, 64, 100, 500} {
+		keys := make([]string, n)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("Key%d", i)
+		}
+		p, err := newPhash(keys)
+		if err != nil {
+			t.Fatalf("%d keys: %s", n, err)
+		}
+		mask := uint32(len(p.disp) - 1)
+		used := 0
+		for i, k := range keys {
+			h := p.hash(k)
+			s := p.slot(h, p.disp[h&mask])
+			if p.slots[s] != i+1 {
+				t.Errorf("%d keys: %q: slot %d holds key %d", n, k, s, p.slots[s]-1)
+			}
+		}
+		for _, k := range p.slots {
+			if k != 0 {
+				used++
+			}
+		}
+		if used != n {
+			t.Errorf("%d keys: %d slots used", n, used)
+		}
+	}
+}
+
+func TestPhashDuplicateKeys(t *testing.T) {
+	if _, err := newPhash([]string{"Key", "Key"}); err == nil {
+		t.Error("expected an error for duplicate keys")
+	}
+}
+
+type TransformTest struct {
+	input                                    string
+	snake, kebab, lower, upper, title, words string
+}
+
+var transformTests = []TransformTest{
+	{"NotFound", "not_found", "not-found", "notfound", "NOT_FOUND", "Not Found", "not found"},
+	{"notFound", "not_found", "not-found", "notfound", "NOT_FOUND", "Not Found", "not found"},
+	{"Not_Found", "not_found", "not-found", "not_found", "NOT_FOUND", "Not Found", "not found"},
+	{"HTTPVersionNotSupported", "http_version_not_supported", "http-version-not-supported", "httpversionnotsupported", "HTTP_VERSION_NOT_SUPPORTED", "HTTP Version Not Supported", "http version not supported"},
+	{"ServeHTTP", "serve_http", "serve-http", "servehttp", "SERVE_HTTP", "Serve HTTP", "serve http"},
+	{"Http2Conn", "http2_conn", "http2-conn", "http2conn", "HTTP2_CONN", "Http2 Conn", "http2 conn"},
+	{"OK", "ok", "ok", "ok", "OK", "OK", "ok"},
+	{"a", "a", "a", "a", "A", "A", "a"},
+	{"_A__B_", "a_b", "a-b", "_a__b_", "A_B", "A B", "a b"},
+}
+
+func TestTransform(t *testing.T) {
+	for _, test := range transformTests {
+		for name, want := range map[string]string{
+			"snake": test.snake,
+			"kebab": test.kebab,
+			"lower": test.lower,
+			"upper": test.upper,
+			"title": test.title,
+			"words": test.words,
+		} {
+			if got := transforms[name](test.input); got != want {
+				t.Errorf("%s(%q) = %q; want %q", name, test.input, got, want)
+			}
+		}
+	}
+}
+
+type TagsTest struct {
+	comment string
+	tags    map[string]string // nil if the comment is not tag-style.
+}
+
+var tagsTests = []TagsTest{
+	{`json:"ok"`, map[string]string{"json": "ok"}},
+	{`json:"ok" sql:"OK" display:"All good"`, map[string]string{"json": "ok", "sql": "OK", "display": "All good"}},
+	{`json:"a\"b"  yaml:""`, map[string]string{"json": `a"b`, "yaml": ""}},
+	{`All good`, nil},
+	{`json: "ok"`, nil},
+	{`json:"ok`, nil},
+	{`json:"ok" trailing`, nil},
+	{`json:"a" json:"b"`, nil},
+	{`:"ok"`, nil},
+	{``, nil},
+}
+
+func TestParseTags(t *testing.T) {
+	for _, test := range tagsTests {
+		c := &ast.CommentGroup{List: []*ast.Comment{{Text: "// " + test.comment}}}
+		tags, ok := parseTags(c)
+		if ok != (test.tags != nil) || !reflect.DeepEqual(tags, test.tags) {
+			t.Errorf("parseTags(%q) = %v, %t; want %v", test.comment, tags, ok, test.tags)
+		}
+	}
+}
+
+var goVersionTests = []struct {
+	version string
+	minor   int
+	ok      bool
+}{
+	{"1.21", 21, true},
+	{"1.21.0", 21, true},
+	{"1.22rc1", 21, true},
+	{"1.100", 21, true},
+	{"1.20", 21, false},
+	{"1.2", 21, false},
+	{"", 21, false},
+}
+
+func TestGoVersionAtLeast(t *testing.T) {
+	for _, test := range goVersionTests {
+		if ok := goVersionAtLeast(test.version, test.minor); ok != test.ok {
+			t.Errorf("goVersionAtLeast(%q, %d) = %t; want %t", test.version, test.minor, ok, test.ok)
+		}
+	}
+}
+
+var graphqlNameTests = []struct {
+	name string
+	ok   bool
+}{
+	{"MONDAY", true},
+	{"not_found", true},
+	{"_Private", true},
+	{"HTTP2", true},
+	{"True", true},
+	{"2FA", false},
+	{"not-found", false},
+	{"not found", false},
+	{"true", false},
+	{"null", false},
+	{"", false},
+}
+
+func TestIsGraphQLName(t *testing.T) {
+	for _, test := range graphqlNameTests {
+		if ok := isGraphQLName(test.name); ok != test.ok {
+			t.Errorf("isGraphQLName(%q) = %t; want %t", test.name, ok, test.ok)
+		}
+	}
+}
@@ -1,2 +1,2 @@
-// This package exists only to benchmark switch vs map lookups.
+// This package exists only to benchmark switch, map and perfect hash lookups.
 package lookup
@@ -172,6 +172,29 @@ var Map8 = map[string]Status{
 	"StatusNonAuthoritativeInfo": StatusNonAuthoritativeInfo,
 }
 
+const _phash8_names = "StatusAcceptedStatusSwitchingProtocolsStatusNonAuthoritativeInfoStatusContinueStatusProcessingStatusCreatedStatusOKStatusEarlyHints"
+
+var (
+	_phash8_index  = [...]uint8{0, 14, 38, 64, 78, 94, 107, 115, 131}
+	_phash8_values = [...]Status{StatusAccepted, StatusSwitchingProtocols, StatusNonAuthoritativeInfo, StatusContinue, StatusProcessing, StatusCreated, StatusOK, StatusEarlyHints}
+	_phash8_disp   = [...]uint32{0, 0}
+	_phash8_slots  = [...]uint8{1, 2, 3, 0, 4, 0, 5, 6, 0, 7, 0, 8, 0, 0, 0, 0}
+)
+
+func Phash8(s string) (Status, bool) {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	h ^= _phash8_disp[h&1]
+	k := int(_phash8_slots[(h*0x9e3779b1)>>28])
+	if k == 0 || s != _phash8_names[_phash8_index[k-1]:_phash8_index[k]] {
+		return 0, false
+	}
+	return _phash8_values[k-1], true
+}
+
 func Switch16(s string) Status {
 	switch s {
 	case "StatusContinue":
@@ -230,6 +253,29 @@ var Map16 = map[string]Status{
 	"StatusMovedPermanently":     StatusMovedPermanently,
 }
 
+const _phash16_names = "StatusAlreadyReportedStatusIMUsedStatusContinueStatusNonAuthoritativeInfoStatusMovedPermanentlyStatusMultipleChoicesStatusProcessingStatusNoContentStatusCreatedStatusMultiStatusStatusOKStatusPartialContentStatusEarlyHintsStatusAcceptedStatusResetContentStatusSwitchingProtocols"
+
+var (
+	_phash16_index  = [...]uint16{0, 21, 33, 47, 73, 95, 116, 132, 147, 160, 177, 185, 205, 221, 235, 253, 277}
+	_phash16_values = [...]Status{StatusAlreadyReported, StatusIMUsed, StatusContinue, StatusNonAuthoritativeInfo, StatusMovedPermanently, StatusMultipleChoices, StatusProcessing, StatusNoContent, StatusCreated, StatusMultiStatus, StatusOK, StatusPartialContent, StatusEarlyHints, StatusAccepted, StatusResetContent, StatusSwitchingProtocols}
+	_phash16_disp   = [...]uint32{0, 7, 7, 2}
+	_phash16_slots  = [...]uint8{1, 0, 0, 0, 2, 3, 0, 0, 4, 5, 0, 6, 0, 7, 0, 8, 0, 9, 10, 0, 11, 12, 13, 0, 0, 0, 0, 0, 14, 15, 0, 16}
+)
+
+func Phash16(s string) (Status, bool) {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	h ^= _phash16_disp[h&3]
+	k := int(_phash16_slots[(h*0x9e3779b1)>>27])
+	if k == 0 || s != _phash16_names[_phash16_index[k-1]:_phash16_index[k]] {
+		return 0, false
+	}
+	return _phash16_values[k-1], true
+}
+
 func Switch32(s string) Status {
 	switch s {
 	case "StatusContinue":
@@ -336,6 +382,29 @@ var Map32 = map[string]Status{
 	"StatusConflict":             StatusConflict,
 }
 
+const _phash32_names = "StatusForbiddenStatusSwitchingProtocolsStatusMultiStatusStatusAlreadyReportedStatusIMUsedStatusNotAcceptableStatusContinueStatusUnauthorizedStatusRequestTimeoutStatusMultipleChoicesStatusConflictStatusAcceptedStatusMethodNotAllowedStatusPermanentRedirectStatusSeeOtherStatusNoContentStatusNonAuthoritativeInfoStatusMovedPermanentlyStatusCreatedStatusPaymentRequiredStatusBadRequestStatusNotModifiedStatusOKStatusPartialContentStatusEarlyHintsStatusProxyAuthRequiredStatusResetContentStatusProcessingStatusFoundStatusUseProxyStatusTemporaryRedirectStatusNotFound"
+
+var (
+	_phash32_index  = [...]uint16{0, 15, 39, 56, 77, 89, 108, 122, 140, 160, 181, 195, 209, 231, 254, 268, 283, 309, 331, 344, 365, 381, 398, 406, 426, 442, 465, 483, 499, 510, 524, 547, 561}
+	_phash32_values = [...]Status{StatusForbidden, StatusSwitchingProtocols, StatusMultiStatus, StatusAlreadyReported, StatusIMUsed, StatusNotAcceptable, StatusContinue, StatusUnauthorized, StatusRequestTimeout, StatusMultipleChoices, StatusConflict, StatusAccepted, StatusMethodNotAllowed, StatusPermanentRedirect, StatusSeeOther, StatusNoContent, StatusNonAuthoritativeInfo, StatusMovedPermanently, StatusCreated, StatusPaymentRequired, StatusBadRequest, StatusNotModified, StatusOK, StatusPartialContent, StatusEarlyHints, StatusProxyAuthRequired, StatusResetContent, StatusProcessing, StatusFound, StatusUseProxy, StatusTemporaryRedirect, StatusNotFound}
+	_phash32_disp   = [...]uint32{0, 7, 3, 0, 7, 1, 0, 12}
+	_phash32_slots  = [...]uint8{0, 1, 0, 0, 2, 0, 3, 4, 5, 0, 0, 6, 0, 0, 0, 0, 7, 8, 0, 9, 0, 0, 10, 11, 0, 0, 12, 13, 14, 0, 15, 16, 17, 18, 19, 0, 20, 0, 0, 21, 22, 23, 24, 0, 0, 25, 26, 0, 27, 0, 0, 28, 29, 0, 0, 0, 0, 30, 0, 0, 31, 32, 0, 0}
+)
+
+func Phash32(s string) (Status, bool) {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	h ^= _phash32_disp[h&7]
+	k := int(_phash32_slots[(h*0x9e3779b1)>>26])
+	if k == 0 || s != _phash32_names[_phash32_index[k-1]:_phash32_index[k]] {
+		return 0, false
+	}
+	return _phash32_values[k-1], true
+}
+
 func Switch64(s string) Status {
 	switch s {
 	case "StatusContinue":
@@ -538,6 +607,29 @@ var Map64 = map[string]Status{
 	"StatusCustomJustDont":                StatusCustomJustDont,
 }
 
+const _phash64_names = "StatusInternalServerErrorStatusFailedDependencyStatusProcessingStatusUnsupportedMediaTypeStatusOKStatusSwitchingProtocolsStatusExpectationFailedStatusAlreadyReportedStatusAcceptedStatusSeeOtherStatusNetworkAuthenticationRequiredStatusLengthRequiredStatusNotExtendedStatusFoundStatusCustomJustDontStatusLockedStatusContinueStatusMethodNotAllowedStatusRequestTimeoutStatusPreconditionRequiredStatusInsufficientStorageStatusMultiStatusStatusNonAuthoritativeInfoStatusCustomIDKStatusTooEarlyStatusRequestEntityTooLargeStatusVariantAlsoNegotiatesStatusEarlyHintsStatusTooManyRequestsStatusGoneStatusPermanentRedirectStatusRequestHeaderFieldsTooLargeStatusTeapotStatusMultipleChoicesStatusGatewayTimeoutStatusPaymentRequiredStatusNotFoundStatusServiceUnavailableStatusHTTPVersionNotSupportedStatusUseProxyStatusNotImplementedStatusBadRequestStatusNotModifiedStatusForbiddenStatusTemporaryRedirectStatusNoContentStatusUnavailableForLegalReasonsStatusProxyAuthRequiredStatusResetContentStatusUnauthorizedStatusMisdirectedRequestStatusBadGatewayStatusRequestURITooLongStatusLoopDetectedStatusCreatedStatusPartialContentStatusIMUsedStatusConflictStatusRequestedRangeNotSatisfiableStatusNotAcceptableStatusMovedPermanentlyStatusUpgradeRequiredStatusPreconditionFailedStatusUnprocessableEntity"
+
+var (
+	_phash64_index  = [...]uint16{0, 25, 47, 63, 89, 97, 121, 144, 165, 179, 193, 228, 248, 265, 276, 296, 308, 322, 344, 364, 390, 415, 432, 458, 473, 487, 514, 541, 557, 578, 588, 611, 644, 656, 677, 697, 718, 732, 756, 785, 799, 819, 835, 852, 867, 890, 905, 937, 960, 978, 996, 1020, 1036, 1059, 1077, 1090, 1110, 1122, 1136, 1170, 1189, 1211, 1232, 1256, 1281}
+	_phash64_values = [...]Status{StatusInternalServerError, StatusFailedDependency, StatusProcessing, StatusUnsupportedMediaType, StatusOK, StatusSwitchingProtocols, StatusExpectationFailed, StatusAlreadyReported, StatusAccepted, StatusSeeOther, StatusNetworkAuthenticationRequired, StatusLengthRequired, StatusNotExtended, StatusFound, StatusCustomJustDont, StatusLocked, StatusContinue, StatusMethodNotAllowed, StatusRequestTimeout, StatusPreconditionRequired, StatusInsufficientStorage, StatusMultiStatus, StatusNonAuthoritativeInfo, StatusCustomIDK, StatusTooEarly, StatusRequestEntityTooLarge, StatusVariantAlsoNegotiates, StatusEarlyHints, StatusTooManyRequests, StatusGone, StatusPermanentRedirect, StatusRequestHeaderFieldsTooLarge, StatusTeapot, StatusMultipleChoices, StatusGatewayTimeout, StatusPaymentRequired, StatusNotFound, StatusServiceUnavailable, StatusHTTPVersionNotSupported, StatusUseProxy, StatusNotImplemented, StatusBadRequest, StatusNotModified, StatusForbidden, StatusTemporaryRedirect, StatusNoContent, StatusUnavailableForLegalReasons, StatusProxyAuthRequired, StatusResetContent, StatusUnauthorized, StatusMisdirectedRequest, StatusBadGateway, StatusRequestURITooLong, StatusLoopDetected, StatusCreated, StatusPartialContent, StatusIMUsed, StatusConflict, StatusRequestedRangeNotSatisfiable, StatusNotAcceptable, StatusMovedPermanently, StatusUpgradeRequired, StatusPreconditionFailed, StatusUnprocessableEntity}
+	_phash64_disp   = [...]uint32{6, 17, 5, 0, 0, 2, 0, 0, 6, 1, 1, 1, 1, 16, 0, 12}
+	_phash64_slots  = [...]uint8{0, 1, 0, 0, 2, 3, 4, 5, 6, 0, 0, 7, 0, 0, 0, 8, 9, 0, 0, 0, 0, 0, 0, 10, 0, 11, 12, 13, 14, 0, 15, 16, 0, 17, 0, 0, 18, 0, 19, 0, 20, 0, 0, 21, 22, 0, 23, 24, 25, 26, 0, 27, 0, 28, 29, 30, 0, 31, 0, 32, 0, 0, 0, 0, 0, 0, 33, 34, 0, 0, 0, 0, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 0, 45, 0, 0, 46, 0, 0, 47, 0, 0, 0, 48, 0, 0, 49, 0, 0, 50, 0, 51, 52, 0, 53, 54, 55, 56, 57, 0, 0, 0, 58, 59, 60, 0, 61, 0, 0, 62, 0, 0, 0, 0, 63, 0, 0, 64}
+)
+
+func Phash64(s string) (Status, bool) {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	h ^= _phash64_disp[h&15]
+	k := int(_phash64_slots[(h*0x9e3779b1)>>25])
+	if k == 0 || s != _phash64_names[_phash64_index[k-1]:_phash64_index[k]] {
+		return 0, false
+	}
+	return _phash64_values[k-1], true
+}
+
 func BenchmarkLookup_Switch8(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Switch8(AllStatuses[i%len(AllStatuses)])
@@ -551,6 +643,13 @@ func BenchmarkLookup_Map8(b *testing.B) {
 	}
 }
 
+func BenchmarkLookup_Phash8(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, ok := Phash8(AllStatuses[i%len(AllStatuses)])
+		_ = ok
+	}
+}
+
 func BenchmarkLookup_Switch16(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Switch16(AllStatuses[i%len(AllStatuses)])
@@ -564,6 +663,13 @@ func BenchmarkLookup_Map16(b *testing.B) {
 	}
 }
 
+func BenchmarkLookup_Phash16(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, ok := Phash16(AllStatuses[i%len(AllStatuses)])
+		_ = ok
+	}
+}
+
 func BenchmarkLookup_Switch32(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Switch32(AllStatuses[i%len(AllStatuses)])
@@ -577,6 +683,13 @@ func BenchmarkLookup_Map32(b *testing.B) {
 	}
 }
 
+func BenchmarkLookup_Phash32(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, ok := Phash32(AllStatuses[i%len(AllStatuses)])
+		_ = ok
+	}
+}
+
 func BenchmarkLookup_Switch64(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Switch64(AllStatuses[i%len(AllStatuses)])
@@ -589,3 +702,10 @@ func BenchmarkLookup_Map64(b *testing.B) {
 		_ = ok
 	}
 }
+
+func BenchmarkLookup_Phash64(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, ok := Phash64(AllStatuses[i%len(AllStatuses)])
+		_ = ok
+	}
+}
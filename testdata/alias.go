@@ -0,0 +1,82 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Constants with duplicate values, one marked as the primary name, and
+// additional names given by the enum:alias directive.
+// Run with -nocase -values.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Alias int
+
+const (
+	Placebo Alias = iota //enum:alias=dummy,sugar pill
+	Aspirin              // enum:alias=asa
+	Ibuprofen
+	Paracetamol
+	Acetaminophen = Paracetamol
+	//enum:primary
+	//enum:alias=brufen
+	Advil     = Ibuprofen
+	Motrin    = Ibuprofen
+	ASPIRIN   = Aspirin
+	Nurofen   = Ibuprofen
+	Panadol   = Paracetamol
+	Tylenol   = Paracetamol //enum:alias=apap
+	Sugarpill = Placebo
+)
+
+func main() {
+	ck(Placebo, "Placebo")
+	ck(Aspirin, "Aspirin")
+	ck(Ibuprofen, "Advil")
+	ck(Paracetamol, "Paracetamol")
+	ckAlias(Acetaminophen, "Acetaminophen")
+	ckAlias(Ibuprofen, "Ibuprofen")
+	ckAlias(Motrin, "motrin")
+	ckAlias(Aspirin, "ASPIRIN")
+	ckAlias(Tylenol, "TYLENOL")
+	ckAlias(Sugarpill, "Sugarpill")
+	ckAlias(Placebo, "dummy")
+	ckAlias(Placebo, "Sugar Pill")
+	ckAlias(Aspirin, "asa")
+	ckAlias(Ibuprofen, "brufen")
+	ckAlias(Paracetamol, "APAP")
+	if n := AliasCount; n != 4 {
+		panic(fmt.Sprintf("alias.go: AliasCount: got: %d want: 4", n))
+	}
+}
+
+func ck(c Alias, str string) {
+	if s := c.String(); s != str {
+		panic(fmt.Sprintf("alias.go: String: got: %q want: %q", s, str))
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		panic("alias.go: json.Marshal: " + err.Error())
+	}
+	if string(b) != `"`+str+`"` {
+		panic(fmt.Sprintf("alias.go: json.Marshal: got: %s want: %q", b, str))
+	}
+	var v Alias
+	if err := json.Unmarshal(b, &v); err != nil || v != c {
+		panic(fmt.Sprintf("alias.go: json.Unmarshal: got: %s, %v want: %s", v, err, c))
+	}
+}
+
+func ckAlias(c Alias, str string) {
+	var v Alias
+	if err := v.Set(str); err != nil || v != c {
+		panic(fmt.Sprintf("alias.go: Set(%q): got: %s, %v want: %s", str, v, err, c))
+	}
+	var u Alias
+	if err := u.UnmarshalText([]byte(str)); err != nil || u != c {
+		panic(fmt.Sprintf("alias.go: UnmarshalText(%q): got: %s, %v want: %s", str, u, err, c))
+	}
+}
@@ -0,0 +1,82 @@
+// Binary marshalers used by encoding/gob.
+// Run with -binary -json.
+
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+type Binary int8
+
+const (
+	Debug Binary = iota - 1
+	Info
+	Warning
+	Error
+)
+
+type Record struct {
+	Level   Binary
+	Message string
+}
+
+func main() {
+	ck(Debug, []byte{0x01})
+	ck(Info, []byte{0x00})
+	ck(Error, []byte{0x04})
+	ckGob(Record{Warning, "disk almost full"})
+	ckGob(Record{Debug, ""})
+
+	if _, err := Binary(9).MarshalBinary(); !errors.Is(err, ErrInvalid) {
+		panic(fmt.Sprintf("binary.go: MarshalBinary(Binary(9)): got: %v want: ErrInvalid", err))
+	}
+	var v Binary
+	if err := v.UnmarshalBinary([]byte{0x12}); !errors.Is(err, ErrInvalid) {
+		panic(fmt.Sprintf("binary.go: UnmarshalBinary(9): got: %v want: ErrInvalid", err))
+	}
+	if err := v.UnmarshalBinary([]byte{0x80, 0x02}); !errors.Is(err, ErrInvalid) {
+		panic(fmt.Sprintf("binary.go: UnmarshalBinary(128): got: %v want: ErrInvalid", err))
+	}
+	if err := v.UnmarshalBinary([]byte{0x80}); !errors.Is(err, ErrMalformed) {
+		panic(fmt.Sprintf("binary.go: UnmarshalBinary(0x80): got: %v want: ErrMalformed", err))
+	}
+	if err := gob.NewEncoder(new(bytes.Buffer)).Encode(Record{Level: 9}); err == nil {
+		panic("binary.go: gob: expected an error encoding Binary(9)")
+	}
+}
+
+func ck(c Binary, data []byte) {
+	b, err := c.MarshalBinary()
+	if err != nil {
+		panic("binary.go: MarshalBinary: " + err.Error())
+	}
+	if !bytes.Equal(b, data) {
+		panic(fmt.Sprintf("binary.go: MarshalBinary(%s): got: %v want: %v", c, b, data))
+	}
+	var v Binary
+	if err := v.UnmarshalBinary(b); err != nil || v != c {
+		panic(fmt.Sprintf("binary.go: UnmarshalBinary(%v): got: %s, %v want: %s", b, v, err, c))
+	}
+}
+
+func ckGob(r Record) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(r); err != nil {
+		panic("binary.go: gob: " + err.Error())
+	}
+	// The level is encoded as a varint rather than as its name.
+	if bytes.Contains(buf.Bytes(), []byte(r.Level.String())) {
+		panic(fmt.Sprintf("binary.go: gob: the name of %s is encoded", r.Level))
+	}
+	var v Record
+	if err := gob.NewDecoder(&buf).Decode(&v); err != nil {
+		panic("binary.go: gob: " + err.Error())
+	}
+	if v != r {
+		panic(fmt.Sprintf("binary.go: gob: got: %+v want: %+v", v, r))
+	}
+}
@@ -33,6 +33,19 @@ func main() {
 	ck(Sunday, "Sunday", false)
 	ck(-127, "Day(-127)", true)
 	ck(127, "Day(127)", true)
+	ckError("Mondya", `malformed Day: "Mondya" (did you mean "Monday"?)`)
+	ckError("sunday", `malformed Day: "sunday" (did you mean "Sunday"?)`)
+	ckError("Day", `malformed Day: "Day" (valid values: "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday")`)
+}
+
+func ckError(str, msg string) {
+	var v Day
+	if err := v.Set(str); err == nil || err.Error() != msg {
+		panic(fmt.Sprintf("day.go: Set: got: %v: want: %s", err, msg))
+	}
+	if err := v.UnmarshalText([]byte(str)); err == nil || err.Error() != msg {
+		panic(fmt.Sprintf("day.go: UnmarshalText: got: %v: want: %s", err, msg))
+	}
 }
 
 func ck(c Day, str string, invalid bool) {
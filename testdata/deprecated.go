@@ -0,0 +1,67 @@
+// Deprecated constants, which are parsed but not listed, and are marshaled as
+// their replacements.
+// Run with -replacedeprecated -values.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Deprecated int
+
+const (
+	// Deprecated: Use Alpha instead.
+	OldAlpha Deprecated = iota
+	Alpha    Deprecated = iota - 1
+	Beta
+	// Deprecated: Use Beta instead.
+	Gamma
+	Delta
+	// Deprecated: No longer supported.
+	Epsilon
+)
+
+func main() {
+	if n := DeprecatedCount; n != 3 {
+		panic(fmt.Sprintf("deprecated.go: DeprecatedCount: got: %d want: 3", n))
+	}
+	ck(Alpha, "Alpha", "Alpha", Alpha)
+	ck(Beta, "Beta", "Beta", Beta)
+	ck(Gamma, "Gamma", "Beta", Beta)
+	ck(Epsilon, "Epsilon", "Epsilon", Epsilon)
+	ckParse("OldAlpha", Alpha)
+	ckParse("Gamma", Gamma)
+	ckParse("Epsilon", Epsilon)
+	if i := Gamma.Index(); i != -1 {
+		panic(fmt.Sprintf("deprecated.go: Gamma.Index: got: %d want: -1", i))
+	}
+	if names := DeprecatedNames(); fmt.Sprint(names) != "[Alpha Beta Delta]" {
+		panic(fmt.Sprintf("deprecated.go: DeprecatedNames: got: %q", names))
+	}
+}
+
+func ck(c Deprecated, str, text string, val Deprecated) {
+	if s := c.String(); s != str {
+		panic(fmt.Sprintf("deprecated.go: String: got: %q want: %q", s, str))
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		panic(fmt.Sprintf("deprecated.go: json.Marshal(%s): %v", c, err))
+	}
+	if s := string(data); s != `"`+text+`"` {
+		panic(fmt.Sprintf("deprecated.go: json.Marshal(%s): got: %s want: %q", c, s, text))
+	}
+	var v Deprecated
+	if err := json.Unmarshal(data, &v); err != nil || v != val {
+		panic(fmt.Sprintf("deprecated.go: json.Unmarshal(%s): got: %s, %v want: %s", data, v, err, val))
+	}
+}
+
+func ckParse(str string, val Deprecated) {
+	var v Deprecated
+	if err := v.Set(str); err != nil || v != val {
+		panic(fmt.Sprintf("deprecated.go: Set(%q): got: %s, %v want: %s", str, v, err, val))
+	}
+}
@@ -0,0 +1,77 @@
+// Values marshaled as numbers, with the names also unmarshaled.
+// Run with -encoding=number,name -sql -yaml.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Encoding int
+
+const (
+	Plain Encoding = iota + 1
+	Gzip
+	Brotli
+	Zstd
+	Deflate = Gzip
+)
+
+func main() {
+	ck(Plain, "1")
+	ck(Brotli, "3")
+	ckUnmarshal(`4`, Zstd)
+	ckUnmarshal(`"4"`, Zstd)
+	ckUnmarshal(`"Gzip"`, Gzip)
+	ckUnmarshal(`"Deflate"`, Gzip)
+	ckInvalid(`0`)
+	ckInvalid(`5`)
+	ckInvalid(`1.5`)
+	ckInvalid(`"Other"`)
+	// The form returned by String for invalid values is only parsed with -lenient.
+	ckInvalid(`"Encoding(1)"`)
+	if _, err := json.Marshal(Encoding(9)); err == nil {
+		panic("encoding.go: json.Marshal(Encoding(9)): expected an error")
+	}
+
+	// Null leaves the value unchanged.
+	v := Zstd
+	if err := json.Unmarshal([]byte(`null`), &v); err != nil || v != Zstd {
+		panic(fmt.Sprintf("encoding.go: json.Unmarshal(null): got: %s, %v", v, err))
+	}
+
+	// The SQL methods still use the names.
+	if s, err := Brotli.Value(); err != nil || s != "Brotli" {
+		panic(fmt.Sprintf("encoding.go: Value: got: %v, %v", s, err))
+	}
+	if err := v.Scan([]byte("Plain")); err != nil || v != Plain {
+		panic(fmt.Sprintf("encoding.go: Scan: got: %s, %v", v, err))
+	}
+}
+
+func ck(c Encoding, data string) {
+	b, err := json.Marshal(c)
+	if err != nil || string(b) != data {
+		panic(fmt.Sprintf("encoding.go: json.Marshal(%s): got: %s, %v want: %s", c, b, err, data))
+	}
+	b, err = c.MarshalText()
+	if err != nil || string(b) != data {
+		panic(fmt.Sprintf("encoding.go: MarshalText(%s): got: %s, %v want: %s", c, b, err, data))
+	}
+	ckUnmarshal(data, c)
+}
+
+func ckUnmarshal(data string, val Encoding) {
+	var v Encoding
+	if err := json.Unmarshal([]byte(data), &v); err != nil || v != val {
+		panic(fmt.Sprintf("encoding.go: json.Unmarshal(%s): got: %s, %v want: %s", data, v, err, val))
+	}
+}
+
+func ckInvalid(data string) {
+	var v Encoding
+	if err := json.Unmarshal([]byte(data), &v); err == nil {
+		panic(fmt.Sprintf("encoding.go: json.Unmarshal(%s): expected an error got: %s", data, v))
+	}
+}
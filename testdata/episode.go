@@ -0,0 +1,53 @@
+// GraphQL marshalers used by gqlgen.
+// Run with -graphql.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Episode int
+
+const (
+	NewHope Episode = iota + 4
+	Empire
+	ReturnOfTheJedi
+)
+
+func main() {
+	ck(NewHope, `"NEW_HOPE"`)
+	ck(Empire, `"EMPIRE"`)
+	ck(ReturnOfTheJedi, `"RETURN_OF_THE_JEDI"`)
+
+	var b strings.Builder
+	Episode(1).MarshalGQL(&b)
+	if b.String() != `"Episode(1)"` {
+		panic(fmt.Sprintf("episode.go: MarshalGQL(Episode(1)): got: %s", b.String()))
+	}
+	var v Episode
+	err := v.UnmarshalGQL("EMPIRES")
+	if !errors.Is(err, ErrMalformed) || !strings.Contains(err.Error(), `did you mean "EMPIRE"?`) {
+		panic(fmt.Sprintf("episode.go: UnmarshalGQL(EMPIRES): got: %v", err))
+	}
+	if err := v.UnmarshalGQL("Empire"); !errors.Is(err, ErrMalformed) {
+		panic(fmt.Sprintf("episode.go: UnmarshalGQL(Empire): got: %v want: ErrMalformed", err))
+	}
+	if err := v.UnmarshalGQL(5); err == nil {
+		panic("episode.go: UnmarshalGQL(5): expected an error")
+	}
+}
+
+func ck(e Episode, data string) {
+	var b strings.Builder
+	e.MarshalGQL(&b)
+	if b.String() != data {
+		panic(fmt.Sprintf("episode.go: MarshalGQL(%s): got: %s want: %s", e, b.String(), data))
+	}
+	var v Episode
+	if err := v.UnmarshalGQL(data[1 : len(data)-1]); err != nil || v != e {
+		panic(fmt.Sprintf("episode.go: UnmarshalGQL(%s): got: %s, %v want: %s", data, v, err, e))
+	}
+}
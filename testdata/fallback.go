@@ -0,0 +1,59 @@
+// Unknown names unmarshaled as the constant marked with enum:default.
+// Run with -linecomment -lookup=map -sql.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Fallback int
+
+const (
+	Unknown Fallback = iota // enum:default
+	Cash
+	Card    // json:"credit_card"
+	Voucher // sql:"VOUCHER"
+)
+
+func main() {
+	ck("Cash", "Cash", "Cash", Cash)
+	ck("Card", "credit_card", "Card", Card)
+	ck("Voucher", "Voucher", "VOUCHER", Voucher)
+	ck("Bitcoin", "Bitcoin", "Bitcoin", Unknown)
+	ck("", "", "", Unknown)
+
+	// Set is strict.
+	var v Fallback
+	if err := v.Set("Bitcoin"); err == nil {
+		panic(fmt.Sprintf("fallback.go: Set(%q): expected an error", "Bitcoin"))
+	}
+	if err := v.Scan(42); err == nil {
+		panic("fallback.go: Scan(42): expected an error")
+	}
+}
+
+func ck(str, text, sql string, val Fallback) {
+	var v Fallback
+	if err := json.Unmarshal([]byte(`"`+text+`"`), &v); err != nil || v != val {
+		panic(fmt.Sprintf("fallback.go: json.Unmarshal(%q): got: %s, %v want: %s", text, v, err, val))
+	}
+	v = Cash
+	if err := v.UnmarshalText([]byte(text)); err != nil || v != val {
+		panic(fmt.Sprintf("fallback.go: UnmarshalText(%q): got: %s, %v want: %s", text, v, err, val))
+	}
+	v = Cash
+	if err := v.Scan(sql); err != nil || v != val {
+		panic(fmt.Sprintf("fallback.go: Scan(%q): got: %s, %v want: %s", sql, v, err, val))
+	}
+	v = Cash
+	if err := v.Scan([]byte(sql)); err != nil || v != val {
+		panic(fmt.Sprintf("fallback.go: Scan(%q): got: %s, %v want: %s", sql, v, err, val))
+	}
+	if val != Unknown {
+		if err := v.Set(str); err != nil || v != val {
+			panic(fmt.Sprintf("fallback.go: Set(%q): got: %s, %v want: %s", str, v, err, val))
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Bit flags marshaled and parsed as names joined by "|".
+// Run with -bitmask -values -json.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Flags uint32
+
+const (
+	Coalesced Flags = 1 << iota
+	Other
+)
+
+func main() {
+	ck(0, "0", false)
+	ck(Coalesced, "Coalesced", false)
+	ck(Other, "Other", false)
+	ck(Coalesced|Other, "Coalesced|Other", false)
+	ck(4, "0x4", true)
+	ck(Other|8, "Other|0x8", true)
+	ck(1<<31|Coalesced, "Coalesced|0x80000000", true)
+}
+
+func ck(c Flags, str string, invalid bool) {
+	if fmt.Sprint(c) != str {
+		panic("flags.go: " + str)
+	}
+	{
+		b, err := json.Marshal(c)
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("flags.go: json.Marshal: expected an error for %s", c))
+			}
+			goto MarshalText
+		}
+		if err != nil {
+			panic("flags.go: " + err.Error())
+		}
+		if string(b) != `"`+str+`"` {
+			panic(fmt.Sprintf("flags.go: json.Marshal: got: %s: want: %q", b, str))
+		}
+		var v Flags
+		if err := json.Unmarshal(b, &v); err != nil {
+			panic("flags.go: json.Unmarshal: " + err.Error())
+		}
+		if v != c {
+			panic(fmt.Sprintf("flags.go: json.Marshal: got: %s: want: %s", v, c))
+		}
+	}
+MarshalText:
+	{
+		b, err := c.MarshalText()
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("flags.go: MarshalText: expected an error for %s", c))
+			}
+			goto Set
+		}
+		if err != nil {
+			panic("flags.go: " + err.Error())
+		}
+		if string(b) != str {
+			panic(fmt.Sprintf("flags.go: MarshalText: got: %s: want: %s", b, str))
+		}
+		var v Flags
+		if err := v.UnmarshalText(b); err != nil {
+			panic("flags.go: UnmarshalText: " + err.Error())
+		}
+		if v != c {
+			panic(fmt.Sprintf("flags.go: MarshalText: got: %s: want: %s", v, c))
+		}
+	}
+Set:
+	{
+		var v Flags
+		err := v.Set(str)
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("flags.go: Set: expected an error for %s", c))
+			}
+			goto Invalid
+		}
+		if v != c {
+			panic(fmt.Sprintf("flags.go: Set: got: %s: want: %s", v, c))
+		}
+	}
+Invalid:
+	if invalid {
+		var v Flags
+		if err := json.Unmarshal([]byte(str), &v); err == nil {
+			panic("flags.go: json.Unmarshal: expected an error for: " + str)
+		}
+		if err := v.UnmarshalText([]byte(str)); err == nil {
+			panic("flags.go: UnmarshalText: expected an error for: " + str)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Invalid values marshaled and parsed in the form T(n), and bare integers.
+// Run with -linecomment -lenient -sql -lookup=map -json.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Lenient int
+
+const (
+	Small  Lenient = 1
+	Medium Lenient = 2
+	Large  Lenient = 3 // json:"large"
+)
+
+func main() {
+	ck(Small, "Small", "Small")
+	ck(Large, "Large", "large")
+	ck(Lenient(7), "Lenient(7)", "Lenient(7)")
+	ck(Lenient(-3), "Lenient(-3)", "Lenient(-3)")
+	ckParse("2", Medium)
+	ckParse("42", Lenient(42))
+	ckParse("Lenient(3)", Large)
+	ckInvalid("Lenient()")
+	ckInvalid("Lenient(7")
+	ckInvalid("Other(7)")
+	ckInvalid("99999999999999999999")
+
+	// The documents with unknown values survive a round-trip.
+	type Doc struct{ Sizes []Lenient }
+	in := Doc{Sizes: []Lenient{Small, 9, Large}}
+	data, err := json.Marshal(in)
+	if err != nil {
+		panic(fmt.Sprintf("lenient.go: json.Marshal: %v", err))
+	}
+	var out Doc
+	if err := json.Unmarshal(data, &out); err != nil || fmt.Sprint(out) != fmt.Sprint(in) {
+		panic(fmt.Sprintf("lenient.go: json.Unmarshal(%s): got: %v, %v want: %v", data, out, err, in))
+	}
+}
+
+func ck(c Lenient, str, text string) {
+	if s := c.String(); s != str {
+		panic(fmt.Sprintf("lenient.go: String: got: %q want: %q", s, str))
+	}
+	b, err := c.MarshalText()
+	if err != nil || string(b) != text {
+		panic(fmt.Sprintf("lenient.go: MarshalText(%s): got: %s, %v want: %s", c, b, err, text))
+	}
+	var v Lenient
+	if err := v.UnmarshalText(b); err != nil || v != c {
+		panic(fmt.Sprintf("lenient.go: UnmarshalText(%s): got: %s, %v want: %s", b, v, err, c))
+	}
+	ckParse(str, c)
+}
+
+func ckParse(str string, val Lenient) {
+	var v Lenient
+	if err := v.Set(str); err != nil || v != val {
+		panic(fmt.Sprintf("lenient.go: Set(%q): got: %s, %v want: %s", str, v, err, val))
+	}
+	v = 0
+	if err := v.Scan(str); err != nil || v != val {
+		panic(fmt.Sprintf("lenient.go: Scan(%q): got: %s, %v want: %s", str, v, err, val))
+	}
+}
+
+func ckInvalid(str string) {
+	var v Lenient
+	if err := v.Set(str); err == nil {
+		panic(fmt.Sprintf("lenient.go: Set(%q): expected an error", str))
+	}
+	if err := v.UnmarshalText([]byte(str)); err == nil {
+		panic(fmt.Sprintf("lenient.go: UnmarshalText(%q): expected an error", str))
+	}
+	if err := v.Scan(str); err == nil {
+		panic(fmt.Sprintf("lenient.go: Scan(%q): expected an error", str))
+	}
+}
@@ -0,0 +1,39 @@
+// Format and GoString methods of bit flags.
+// Run with -formatter -bitmask.
+
+package main
+
+import "fmt"
+
+type Mode uint8
+
+const (
+	None Mode = 0
+	Read Mode = 1 << iota
+	Write
+	Exec
+)
+
+func main() {
+	ck("%s", Read|Write, "Read|Write")
+	ck("%v", Exec, "Exec")
+	ck("%-8v|", Write, "Write   |")
+	ck("%q", Read, `"Read"`)
+	ck("%d", Read|Exec, "10")
+	ck("%#x", Write|Exec, "0xc")
+	ck("%03o", Exec, "010")
+	ck("%+v", Read|Write, "Mode(Read|Write=6)")
+	ck("%+v", None, "Mode(None=0)")
+	ck("%+v", Mode(1), "0x1")
+	ck("%#v", None, "main.None")
+	ck("%#v", Read, "main.Read")
+	ck("%#v", Read|Exec, "main.Read|main.Exec")
+	ck("%#v", Write|Mode(1), "main.Write|main.Mode(1)")
+	ck("%#v", []Mode{Read, Exec}, "[]main.Mode{main.Read, main.Exec}")
+}
+
+func ck(format string, v interface{}, str string) {
+	if s := fmt.Sprintf(format, v); s != str {
+		panic(fmt.Sprintf("mode.go: Sprintf(%q, %d): got: %q want: %q", format, v, s, str))
+	}
+}
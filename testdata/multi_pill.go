@@ -0,0 +1,38 @@
+// Constants of a type declared in two files, with an alias in the second file
+// of a constant declared in this one. Used by TestMultipleFiles.
+
+package main
+
+import "fmt"
+
+type Pill int
+
+const (
+	Placebo Pill = iota
+	Aspirin
+	Ibuprofen
+	Paracetamol
+)
+
+func main() {
+	ck(Aspirin, "Aspirin")
+	ck(Paracetamol, "Paracetamol")
+	ckParse("Acetaminophen", Paracetamol)
+	ckParse("Paracetamol", Paracetamol)
+	if PillCount != 4 {
+		panic(fmt.Sprintf("multi_pill.go: PillCount: got: %d want: 4", PillCount))
+	}
+}
+
+func ck(c Pill, str string) {
+	if s := c.String(); s != str {
+		panic(fmt.Sprintf("multi_pill.go: String: got: %q want: %q", s, str))
+	}
+}
+
+func ckParse(str string, c Pill) {
+	var v Pill
+	if err := v.Set(str); err != nil || v != c {
+		panic(fmt.Sprintf("multi_pill.go: Set(%q): got: %s, %v want: %s", str, v, err, c))
+	}
+}
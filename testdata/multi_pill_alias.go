@@ -0,0 +1,4 @@
+package main
+
+// Acetaminophen is an alias of a constant declared in multi_pill.go.
+const Acetaminophen = Paracetamol
@@ -0,0 +1,158 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Case-insensitive parsing with enough values to use a map lookup.
+// Run with -nocase.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type Nocase int
+
+const (
+	Hydrogen Nocase = iota + 1
+	Helium
+	Lithium
+	Beryllium
+	Boron
+	Carbon
+	Nitrogen
+	Oxygen
+	Fluorine
+	Neon
+	Sodium
+	Magnesium
+	Aluminium
+	Silicon
+	Phosphorus
+	Sulfur
+	Chlorine
+	Argon
+	Potassium
+	Calcium
+	Scandium
+	Titanium
+	Vanadium
+	Chromium
+	Manganese
+	Iron
+	Cobalt
+	Nickel
+	Copper
+	Zinc
+	Gallium
+	Germanium
+	Arsenic
+	Selenium
+	Bromine
+	Krypton
+)
+
+func main() {
+	ck(Hydrogen, "Hydrogen", false)
+	ck(Oxygen, "Oxygen", false)
+	ck(Krypton, "Krypton", false)
+	ck(0, "Nocase(0)", true)
+	ck(37, "Nocase(37)", true)
+	ckFold(Hydrogen, "hydrogen")
+	ckFold(Oxygen, "OXYGEN")
+	ckFold(Krypton, "kRyPtOn")
+	// Folded as by strings.EqualFold: the Kelvin sign and the long s.
+	ckFold(Krypton, "\u212Arypton")
+	ckFold(Silicon, "\u017Filicon")
+}
+
+func ckFold(c Nocase, str string) {
+	var v Nocase
+	if err := v.Set(str); err != nil || v != c {
+		panic(fmt.Sprintf("nocase.go: Set(%q): got: %s, %v want: %s", str, v, err, c))
+	}
+	v = 0
+	if err := json.Unmarshal([]byte(`"`+str+`"`), &v); err != nil || v != c {
+		panic(fmt.Sprintf("nocase.go: json.Unmarshal(%q): got: %s, %v want: %s", str, v, err, c))
+	}
+	if s := c.String(); s == str || !strings.EqualFold(s, str) {
+		panic(fmt.Sprintf("nocase.go: String: got: %q want: %q", s, c))
+	}
+}
+
+func ck(c Nocase, str string, invalid bool) {
+	if fmt.Sprint(c) != str {
+		panic("nocase.go: " + str)
+	}
+	{
+		b, err := json.Marshal(c)
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("nocase.go: json.Marshal: expected an error for %s", c))
+			}
+			goto MarshalText
+		}
+		if err != nil {
+			panic("nocase.go: " + err.Error())
+		}
+		if string(b) != `"`+str+`"` {
+			panic(fmt.Sprintf("nocase.go: json.Marshal: got: %s: want: %q", b, str))
+		}
+		var v Nocase
+		if err := json.Unmarshal(b, &v); err != nil {
+			panic("nocase.go: json.Unmarshal: " + err.Error())
+		}
+		if v != c {
+			panic(fmt.Sprintf("nocase.go: json.Marshal: got: %s: want: %s", v, c))
+		}
+	}
+MarshalText:
+	{
+		b, err := c.MarshalText()
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("nocase.go: MarshalText: expected an error for %s", c))
+			}
+			goto Set
+		}
+		if err != nil {
+			panic("nocase.go: " + err.Error())
+		}
+		if string(b) != str {
+			panic(fmt.Sprintf("nocase.go: MarshalText: got: %s: want: %s", b, str))
+		}
+		var v Nocase
+		if err := v.UnmarshalText(b); err != nil {
+			panic("nocase.go: UnmarshalText: " + err.Error())
+		}
+		if v != c {
+			panic(fmt.Sprintf("nocase.go: MarshalText: got: %s: want: %s", v, c))
+		}
+	}
+Set:
+	{
+		var v Nocase
+		err := v.Set(str)
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("nocase.go: Set: expected an error for %s", c))
+			}
+			goto Invalid
+		}
+		if v != c {
+			panic(fmt.Sprintf("nocase.go: Set: got: %s: want: %s", v, c))
+		}
+	}
+Invalid:
+	if invalid {
+		var v Nocase
+		if err := json.Unmarshal([]byte(str), &v); err == nil {
+			panic("nocase.go: json.Unmarshal: expected an error for: " + str)
+		}
+		if err := v.UnmarshalText([]byte(str)); err == nil {
+			panic("nocase.go: UnmarshalText: expected an error for: " + str)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// GraphQL marshalers of a type whose text marshalers are lenient.
+// Run with -graphql -lenient.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Rating int8
+
+const (
+	Unrated Rating = iota
+	Good
+	Great
+)
+
+func main() {
+	var v Rating
+	if err := v.UnmarshalGQL("GREAT"); err != nil || v != Great {
+		panic(fmt.Sprintf("rating.go: UnmarshalGQL(GREAT): got: %s, %v want: %s", v, err, Great))
+	}
+	// The text marshalers accept the numbers and invalid values...
+	if err := v.UnmarshalText([]byte("Rating(7)")); err != nil || v != 7 {
+		panic(fmt.Sprintf("rating.go: UnmarshalText(Rating(7)): got: %s, %v want: Rating(7)", v, err))
+	}
+	// ...but GraphQL enums only accept the names in the schema.
+	for _, s := range []string{"Rating(7)", "7", "1"} {
+		if err := v.UnmarshalGQL(s); !errors.Is(err, ErrMalformed) {
+			panic(fmt.Sprintf("rating.go: UnmarshalGQL(%s): got: %v want: ErrMalformed", s, err))
+		}
+	}
+	var b strings.Builder
+	Rating(7).MarshalGQL(&b)
+	if b.String() != `"Rating(7)"` {
+		panic(fmt.Sprintf("rating.go: MarshalGQL(Rating(7)): got: %s", b.String()))
+	}
+}
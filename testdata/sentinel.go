@@ -0,0 +1,52 @@
+// Sentinel constants excluded by a pattern and by the enum:skip directive.
+// Run with -exclude=^num -values.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Sentinel int
+
+const (
+	Red Sentinel = iota
+	Green
+	Blue
+	numSentinels
+	sentinelMax = Blue // enum:skip
+)
+
+func main() {
+	if n := SentinelCount; n != 3 {
+		panic(fmt.Sprintf("sentinel.go: SentinelCount: got: %d want: 3", n))
+	}
+	ck(Red, "Red", true)
+	ck(Blue, "Blue", true)
+	ck(numSentinels, "Sentinel(3)", false)
+	ckInvalid("numSentinels")
+	ckInvalid("sentinelMax")
+}
+
+func ck(c Sentinel, str string, valid bool) {
+	if s := c.String(); s != str {
+		panic(fmt.Sprintf("sentinel.go: String: got: %q want: %q", s, str))
+	}
+	if c.Valid() != valid {
+		panic(fmt.Sprintf("sentinel.go: Valid(%s): got: %t want: %t", c, c.Valid(), valid))
+	}
+	if _, err := json.Marshal(c); (err == nil) != valid {
+		panic(fmt.Sprintf("sentinel.go: json.Marshal(%s): got: %v", c, err))
+	}
+}
+
+func ckInvalid(str string) {
+	var v Sentinel
+	if err := v.Set(str); err == nil {
+		panic(fmt.Sprintf("sentinel.go: Set(%q): expected an error", str))
+	}
+	if err := json.Unmarshal([]byte(`"`+str+`"`), &v); err == nil {
+		panic(fmt.Sprintf("sentinel.go: json.Unmarshal(%q): expected an error", str))
+	}
+}
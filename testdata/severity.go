@@ -0,0 +1,40 @@
+// LogValue method returning a group of the name and value.
+// Run with -slog=group.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+)
+
+type Severity int
+
+const (
+	Low Severity = iota + 1
+	High
+	Critical
+)
+
+func main() {
+	ck(Low, "sev.name=Low sev.value=1")
+	ck(Critical, "sev.name=Critical sev.value=3")
+	ck(Severity(42), "sev.name=Severity(42) sev.value=42")
+}
+
+func ck(c Severity, attrs string) {
+	var buf bytes.Buffer
+	h := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key != "sev" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	slog.New(h).Info("", "sev", c)
+	if got := buf.String(); got != attrs+"\n" {
+		panic(fmt.Sprintf("severity.go: got: %q want: %q", got, attrs))
+	}
+}
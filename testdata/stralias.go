@@ -0,0 +1,45 @@
+// String enum with additional names given by the enum:alias directive.
+// Run with -nocase -lookup=map -json -sql.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Stralias string
+
+const (
+	Red   Stralias = "red" //enum:alias=rouge
+	Green Stralias = "green"
+	Blue  Stralias = "blue" //enum:alias=bleu,azul
+)
+
+func main() {
+	ck("red", Red)
+	ck("Rouge", Red)
+	ck("green", Green)
+	ck("bleu", Blue)
+	ck("AZUL", Blue)
+	var v Stralias
+	if err := v.Set("purple"); err == nil {
+		panic("stralias.go: Set(purple): expected an error")
+	}
+	if err := json.Unmarshal([]byte(`"rouge"`), &v); err != nil || v != Red {
+		panic(fmt.Sprintf("stralias.go: json.Unmarshal(rouge): got: %s, %v want: %s", v, err, Red))
+	}
+	if err := v.Scan("azul"); err != nil || v != Blue {
+		panic(fmt.Sprintf("stralias.go: Scan(azul): got: %s, %v want: %s", v, err, Blue))
+	}
+}
+
+func ck(s string, exp Stralias) {
+	var v Stralias
+	if err := v.Set(s); err != nil || v != exp {
+		panic(fmt.Sprintf("stralias.go: Set(%q): got: %s, %v want: %s", s, v, err, exp))
+	}
+	if err := v.UnmarshalText([]byte(s)); err != nil || v != exp {
+		panic(fmt.Sprintf("stralias.go: UnmarshalText(%q): got: %s, %v want: %s", s, v, err, exp))
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// String enum with a value that is not a valid identifier.
+// Run with -sql -values -json -yaml -xml.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Strenum string
+
+const (
+	Red       Strenum = "red"
+	Green     Strenum = "green"
+	Blue      Strenum = "blue"
+	LightGray Strenum = "light gray"
+	Quoted    Strenum = `"quoted"`
+)
+
+func main() {
+	ck(Red, "red", false)
+	ck(Green, "green", false)
+	ck(Blue, "blue", false)
+	ck(LightGray, "light gray", false)
+	ck(Quoted, `"quoted"`, false)
+	ck("", "", true)
+	ck("Red", "Red", true)
+	ck("purple", "purple", true)
+	if v, err := Red.Value(); err != nil || v != "red" {
+		panic(fmt.Sprintf("strenum.go: Value: got: %v, %v want: red", v, err))
+	}
+	var v Strenum
+	if err := v.Scan([]byte("light gray")); err != nil || v != LightGray {
+		panic(fmt.Sprintf("strenum.go: Scan: got: %s, %v want: %s", v, err, LightGray))
+	}
+	if n := Quoted.Index(); n != 0 {
+		panic(fmt.Sprintf("strenum.go: Index: got: %d want: 0", n))
+	}
+}
+
+func ck(c Strenum, str string, invalid bool) {
+	if fmt.Sprint(c) != str {
+		panic("strenum.go: " + str)
+	}
+	if c.Valid() == invalid {
+		panic(fmt.Sprintf("strenum.go: Valid: got: %t want: %t", c.Valid(), !invalid))
+	}
+	{
+		b, err := json.Marshal(c)
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("strenum.go: json.Marshal: expected an error for %s", c))
+			}
+			goto MarshalText
+		}
+		if err != nil {
+			panic("strenum.go: " + err.Error())
+		}
+		exp, _ := json.Marshal(str)
+		if string(b) != string(exp) {
+			panic(fmt.Sprintf("strenum.go: json.Marshal: got: %s: want: %s", b, exp))
+		}
+		var v Strenum
+		if err := json.Unmarshal(b, &v); err != nil {
+			panic("strenum.go: json.Unmarshal: " + err.Error())
+		}
+		if v != c {
+			panic(fmt.Sprintf("strenum.go: json.Marshal: got: %s: want: %s", v, c))
+		}
+	}
+MarshalText:
+	{
+		b, err := c.MarshalText()
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("strenum.go: MarshalText: expected an error for %s", c))
+			}
+			goto Set
+		}
+		if err != nil {
+			panic("strenum.go: " + err.Error())
+		}
+		if string(b) != str {
+			panic(fmt.Sprintf("strenum.go: MarshalText: got: %s: want: %s", b, str))
+		}
+		var v Strenum
+		if err := v.UnmarshalText(b); err != nil {
+			panic("strenum.go: UnmarshalText: " + err.Error())
+		}
+		if v != c {
+			panic(fmt.Sprintf("strenum.go: MarshalText: got: %s: want: %s", v, c))
+		}
+	}
+Set:
+	{
+		var v Strenum
+		err := v.Set(str)
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("strenum.go: Set: expected an error for %s", c))
+			}
+			if v != "" {
+				panic(fmt.Sprintf("strenum.go: Set: modified the value on error: %s", v))
+			}
+			return
+		}
+		if v != c {
+			panic(fmt.Sprintf("strenum.go: Set: got: %s: want: %s", v, c))
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Names given by tag-style line comments.
+// Run with -sql -nocase -linecomment -json -yaml -slog=name.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Tags int
+
+const (
+	TagsOK       Tags = 200 // json:"ok" sql:"OK" display:"All good"
+	TagsCreated  Tags = 201 // json:"created"
+	TagsAccepted Tags = 202 // display:"Accepted for processing"
+	TagsNotFound Tags = 404 // Not Found
+	TagsGone     Tags = 410
+	TagsFine          = TagsOK // json:"fine" sql:"FINE"
+)
+
+func main() {
+	ck(TagsOK, "All good", "ok", "OK")
+	ck(TagsCreated, "TagsCreated", "created", "TagsCreated")
+	ck(TagsAccepted, "Accepted for processing", "TagsAccepted", "TagsAccepted")
+	ck(TagsNotFound, "Not Found", "Not Found", "Not Found")
+	ck(TagsGone, "TagsGone", "TagsGone", "TagsGone")
+	ckParse(TagsOK, "TagsFine", "fine", "FINE")
+	ckParse(TagsOK, "all GOOD", "OK", "ok")
+	ckInvalid("ok", "All good", "All good")
+	ckInvalid("TagsOK", "TagsOK", "TagsOK")
+}
+
+func ck(c Tags, str, text, sql string) {
+	if fmt.Sprint(c) != str {
+		panic(fmt.Sprintf("tags.go: String: got: %s want: %s", c, str))
+	}
+	b, err := c.MarshalText()
+	if err != nil || string(b) != text {
+		panic(fmt.Sprintf("tags.go: MarshalText: got: %s, %v want: %s", b, err, text))
+	}
+	b, err = json.Marshal(c)
+	if err != nil || string(b) != `"`+text+`"` {
+		panic(fmt.Sprintf("tags.go: json.Marshal: got: %s, %v want: %q", b, err, text))
+	}
+	v, err := c.Value()
+	if err != nil || v != sql {
+		panic(fmt.Sprintf("tags.go: Value: got: %v, %v want: %s", v, err, sql))
+	}
+	ckParse(c, str, text, sql)
+}
+
+// ckParse checks that the strings are parsed as c by Set, UnmarshalText and
+// Scan.
+func ckParse(c Tags, str, text, sql string) {
+	var v Tags
+	if err := v.Set(str); err != nil || v != c {
+		panic(fmt.Sprintf("tags.go: Set(%q): got: %s, %v want: %s", str, v, err, c))
+	}
+	v = 0
+	if err := v.UnmarshalText([]byte(text)); err != nil || v != c {
+		panic(fmt.Sprintf("tags.go: UnmarshalText(%q): got: %s, %v want: %s", text, v, err, c))
+	}
+	v = 0
+	if err := json.Unmarshal([]byte(`"`+text+`"`), &v); err != nil || v != c {
+		panic(fmt.Sprintf("tags.go: json.Unmarshal(%q): got: %s, %v want: %s", text, v, err, c))
+	}
+	v = 0
+	if err := v.Scan(sql); err != nil || v != c {
+		panic(fmt.Sprintf("tags.go: Scan(%q): got: %s, %v want: %s", sql, v, err, c))
+	}
+	v = 0
+	if err := v.Scan([]byte(sql)); err != nil || v != c {
+		panic(fmt.Sprintf("tags.go: Scan([]byte(%q)): got: %s, %v want: %s", sql, v, err, c))
+	}
+}
+
+// ckInvalid checks that the strings are not parsed by Set, UnmarshalText and
+// Scan, as they are names of the other formats.
+func ckInvalid(str, text, sql string) {
+	var v Tags
+	if err := v.Set(str); err == nil {
+		panic(fmt.Sprintf("tags.go: Set(%q): expected an error", str))
+	}
+	if err := v.UnmarshalText([]byte(text)); err == nil {
+		panic(fmt.Sprintf("tags.go: UnmarshalText(%q): expected an error", text))
+	}
+	if err := v.Scan(sql); err == nil {
+		panic(fmt.Sprintf("tags.go: Scan(%q): expected an error", sql))
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Transformed names, with a line comment taking precedence.
+// Run with -trimprefix=Transform -transform=kebab -linecomment.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Transform int
+
+const (
+	TransformNotFound Transform = iota
+	TransformHTTPVersionNotSupported
+	TransformServeHTTP
+	TransformHTTP2Conn
+	TransformOK
+	TransformLineComment // Line Comment
+)
+
+func main() {
+	ck(TransformNotFound, "not-found", false)
+	ck(TransformHTTPVersionNotSupported, "http-version-not-supported", false)
+	ck(TransformServeHTTP, "serve-http", false)
+	ck(TransformHTTP2Conn, "http2-conn", false)
+	ck(TransformOK, "ok", false)
+	ck(TransformLineComment, "Line Comment", false)
+	ck(6, "Transform(6)", true)
+}
+
+func ck(c Transform, str string, invalid bool) {
+	if fmt.Sprint(c) != str {
+		panic("transform.go: " + str)
+	}
+	{
+		b, err := json.Marshal(c)
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("transform.go: json.Marshal: expected an error for %s", c))
+			}
+			goto MarshalText
+		}
+		if err != nil {
+			panic("transform.go: " + err.Error())
+		}
+		if string(b) != `"`+str+`"` {
+			panic(fmt.Sprintf("transform.go: json.Marshal: got: %s: want: %q", b, str))
+		}
+		var v Transform
+		if err := json.Unmarshal(b, &v); err != nil {
+			panic("transform.go: json.Unmarshal: " + err.Error())
+		}
+		if v != c {
+			panic(fmt.Sprintf("transform.go: json.Marshal: got: %s: want: %s", v, c))
+		}
+	}
+MarshalText:
+	{
+		b, err := c.MarshalText()
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("transform.go: MarshalText: expected an error for %s", c))
+			}
+			goto Set
+		}
+		if err != nil {
+			panic("transform.go: " + err.Error())
+		}
+		if string(b) != str {
+			panic(fmt.Sprintf("transform.go: MarshalText: got: %s: want: %s", b, str))
+		}
+		var v Transform
+		if err := v.UnmarshalText(b); err != nil {
+			panic("transform.go: UnmarshalText: " + err.Error())
+		}
+		if v != c {
+			panic(fmt.Sprintf("transform.go: MarshalText: got: %s: want: %s", v, c))
+		}
+	}
+Set:
+	{
+		var v Transform
+		err := v.Set(str)
+		if invalid {
+			if err == nil {
+				panic(fmt.Sprintf("transform.go: Set: expected an error for %s", c))
+			}
+			goto Invalid
+		}
+		if v != c {
+			panic(fmt.Sprintf("transform.go: Set: got: %s: want: %s", v, c))
+		}
+	}
+Invalid:
+	if invalid {
+		var v Transform
+		if err := json.Unmarshal([]byte(str), &v); err == nil {
+			panic("transform.go: json.Unmarshal: expected an error for: " + str)
+		}
+		if err := v.UnmarshalText([]byte(str)); err == nil {
+			panic("transform.go: UnmarshalText: expected an error for: " + str)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Values in XML attributes and elements.
+// Run with -xml.
+
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+type Unit int
+
+const (
+	Celsius Unit = iota
+	Fahrenheit
+	Kelvin
+)
+
+type Reading struct {
+	XMLName xml.Name `xml:"reading"`
+	Unit    Unit     `xml:"unit,attr"`
+	Display Unit     `xml:"display"`
+	Value   float64  `xml:"value"`
+}
+
+func main() {
+	ck(Reading{Unit: Celsius, Display: Kelvin, Value: 21.5},
+		`<reading unit="Celsius"><display>Kelvin</display><value>21.5</value></reading>`)
+	ck(Reading{Unit: Fahrenheit, Display: Fahrenheit, Value: -40},
+		`<reading unit="Fahrenheit"><display>Fahrenheit</display><value>-40</value></reading>`)
+	ckInvalid(`<reading unit="Rankine"><display>Kelvin</display></reading>`)
+	ckInvalid(`<reading unit="Kelvin"><display>1</display></reading>`)
+	ckInvalid(`<reading unit=""></reading>`)
+	if _, err := xml.Marshal(Reading{Unit: 7}); !errors.Is(err, ErrInvalid) {
+		panic(fmt.Sprintf("unit.go: xml.Marshal: got: %v want: ErrInvalid", err))
+	}
+	if _, err := xml.Marshal(Reading{Display: 7}); !errors.Is(err, ErrInvalid) {
+		panic(fmt.Sprintf("unit.go: xml.Marshal: got: %v want: ErrInvalid", err))
+	}
+}
+
+func ck(r Reading, data string) {
+	b, err := xml.Marshal(r)
+	if err != nil {
+		panic("unit.go: xml.Marshal: " + err.Error())
+	}
+	if string(b) != data {
+		panic(fmt.Sprintf("unit.go: xml.Marshal: got: %s want: %s", b, data))
+	}
+	var v Reading
+	if err := xml.Unmarshal(b, &v); err != nil {
+		panic("unit.go: xml.Unmarshal: " + err.Error())
+	}
+	r.XMLName = v.XMLName
+	if v != r {
+		panic(fmt.Sprintf("unit.go: xml.Unmarshal: got: %+v want: %+v", v, r))
+	}
+}
+
+func ckInvalid(data string) {
+	var v Reading
+	if err := xml.Unmarshal([]byte(data), &v); !errors.Is(err, ErrMalformed) {
+		panic(fmt.Sprintf("unit.go: xml.Unmarshal(%s): got: %v want: ErrMalformed", data, err))
+	}
+}
@@ -0,0 +1,40 @@
+// Unsigned values above math.MaxInt64 marshaled as numbers.
+// Run with -encoding=number -lenient.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Unsigned uint64
+
+const (
+	Low  Unsigned = 1
+	High Unsigned = 1<<64 - 1
+)
+
+func main() {
+	ck(Low, "1")
+	ck(High, "18446744073709551615")
+	ck(1<<63, "9223372036854775808")
+	var v Unsigned
+	if err := v.UnmarshalText([]byte("-1")); err == nil {
+		panic(fmt.Sprintf("unsigned.go: UnmarshalText(-1): expected an error got: %s", v))
+	}
+	if err := v.UnmarshalText([]byte("Unsigned(-2)")); err != nil || v != High-1 {
+		panic(fmt.Sprintf("unsigned.go: UnmarshalText(Unsigned(-2)): got: %s, %v want: %d", v, err, High-1))
+	}
+}
+
+func ck(c Unsigned, data string) {
+	b, err := json.Marshal(c)
+	if err != nil || string(b) != data {
+		panic(fmt.Sprintf("unsigned.go: json.Marshal(%s): got: %s, %v want: %s", c, b, err, data))
+	}
+	var v Unsigned
+	if err := json.Unmarshal(b, &v); err != nil || v != c {
+		panic(fmt.Sprintf("unsigned.go: json.Unmarshal(%s): got: %s, %v want: %s", b, v, err, c))
+	}
+}